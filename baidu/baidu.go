@@ -0,0 +1,255 @@
+package baidu
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "baidu"
+
+const (
+	searchURL   = "https://www.baidu.com/s"
+	siteTag     = "site:"
+	urlTag      = "inurl:"
+	filetypeTag = "filetype:"
+	excludeTag  = "-"
+	intitleTag  = "intitle:"
+	operatorOr  = "|"
+	operatorAnd = "+"
+	encoding    = "utf-8"
+)
+
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:     siteTag,
+		query.OpInURL:    urlTag,
+		query.OpFileType: filetypeTag,
+		query.OpInTitle:  intitleTag,
+	},
+	And: operatorAnd,
+	Or:  operatorOr,
+	Not: excludeTag,
+}
+
+// Baidu is the Baidu search implementation for Dorkgen
+type Baidu struct {
+	nodes   query.Query
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of Baidu
+type Option func(*Baidu)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *Baidu) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *Baidu) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of Baidu
+func New(opts ...Option) *Baidu {
+	e := &Baidu{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of Baidu from a query tree
+func FromQuery(q query.Query) *Baidu {
+	return &Baidu{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of Baidu.
+// Excluded groups are rejected, since Baidu doesn't support them.
+func Parse(dork string) (*Baidu, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.ValidateExclusions(q); err != nil {
+		return nil, err
+	}
+
+	return &Baidu{nodes: q}, nil
+}
+
+// Render converts a query tree to Baidu syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in Baidu
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Baidu
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *Baidu) add(n query.Node) *Baidu {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *Baidu) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *Baidu) operator(name string, value string, quotes bool) *Baidu {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *Baidu) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *Baidu) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *Baidu) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+// Baidu doesn't support excluding a group as a whole.
+func (e *Baidu) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := query.Validate(e.nodes); err != nil {
+		return err
+	}
+
+	return query.ValidateExclusions(e.nodes)
+}
+
+// String converts all tags to a single request
+func (e *Baidu) String() string {
+	return syntax.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values.
+// The request is sent as UTF-8, which Baidu expects to be declared by the ie parameter.
+// Invalid UTF-8 sequences are dropped.
+func (e *Baidu) QueryValues() url.Values {
+	params := url.Values{}
+	params.Set("wd", strings.ToValidUTF8(e.String(), ""))
+	params.Set("ie", encoding)
+
+	return params
+}
+
+// URL converts tags to an encoded Baidu URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *Baidu) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded Baidu URL, or returns an error if the base URL is invalid.
+func (e *Baidu) URLE() (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, e.QueryValues())
+}
+
+// Site specifically searches that particular site and lists all the results for that site.
+func (e *Baidu) Site(site string) *Baidu {
+	return e.operator(query.OpSite, site, false)
+}
+
+// Or puts an OR operator in the request
+func (e *Baidu) Or() *Baidu {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request
+func (e *Baidu) And() *Baidu {
+	return e.add(query.And{})
+}
+
+// InURL searches for a URL matching one of the keywords.
+func (e *Baidu) InURL(url string) *Baidu {
+	return e.operator(query.OpInURL, url, false)
+}
+
+// InTitle searches for occurrences of keywords in title all or one.
+func (e *Baidu) InTitle(value string) *Baidu {
+	return e.operator(query.OpInTitle, value, true)
+}
+
+// FileType searches for a particular filetype mentioned in the query, such as "pdf" or "doc".
+func (e *Baidu) FileType(filetype string) *Baidu {
+	return e.operator(query.OpFileType, filetype, false)
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Baidu doesn't support excluding groups, which report an error.
+func (e *Baidu) Exclude(tags *Baidu) *Baidu {
+	nodes, err := query.Exclude(tags.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *Baidu) Not(tag *Baidu) *Baidu {
+	n, err := query.Negate(tag.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *Baidu) Group(tags *Baidu) *Baidu {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *Baidu) Plain(value string) *Baidu {
+	return e.add(query.Term{Text: value})
+}
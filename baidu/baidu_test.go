@@ -0,0 +1,153 @@
+package baidu_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/baidu"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+var dork *baidu.Baidu
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = baidu.New()
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal("https://www.baidu.com/s?ie=utf-8&wd=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = baidu.New()
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal("site:example.com", result, "they should be equal")
+	})
+
+	t.Run("should handle operators correctly", func(t *testing.T) {
+		dork = baidu.New()
+
+		result := dork.
+			Site("example.cn").
+			InURL("admin").
+			InTitle("后台管理").
+			FileType("pdf").
+			String()
+
+		assert.Equal("site:example.cn inurl:admin intitle:\"后台管理\" filetype:pdf", result, "they should be equal")
+	})
+
+	t.Run("should handle or, and and groups correctly", func(t *testing.T) {
+		dork = baidu.New()
+
+		result := dork.
+			Group(baidu.New().Site("a.cn").Or().Site("b.cn")).
+			And().
+			Plain("登录").
+			String()
+
+		assert.Equal("(site:a.cn | site:b.cn) + 登录", result, "they should be equal")
+	})
+
+	t.Run("should handle exclusions correctly", func(t *testing.T) {
+		dork = baidu.New()
+
+		result := dork.
+			Site("example.cn").
+			Exclude(baidu.New().InURL("login").FileType("doc")).
+			String()
+
+		assert.Equal("site:example.cn -inurl:login -filetype:doc", result, "they should be equal")
+		assert.True(errors.Is(baidu.New().Exclude(baidu.New().Group(baidu.New().Site("a.cn"))).Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+
+	t.Run("should encode UTF-8 keywords", func(t *testing.T) {
+		dork = baidu.New().InTitle("密码")
+
+		assert.Equal(url.Values{
+			"wd": []string{"intitle:\"密码\""},
+			"ie": []string{"utf-8"},
+		}, dork.QueryValues(), "they should be equal")
+		assert.Equal("https://www.baidu.com/s?ie=utf-8&wd=intitle%3A%22%E5%AF%86%E7%A0%81%22", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should drop invalid UTF-8 sequences", func(t *testing.T) {
+		dork = baidu.New().Plain("密\xff码")
+
+		assert.Equal("密码", dork.QueryValues().Get("wd"), "they should be equal")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = baidu.New(baidu.WithBaseURL("http://localhost:8080/s?rn=50")).Site("example.cn")
+
+		assert.Equal("http://localhost:8080/s?ie=utf-8&rn=50&wd=site%3Aexample.cn", dork.URL(), "they should be equal")
+
+		_, err := baidu.New(baidu.WithBaseURL("s")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+
+	t.Run("should validate the request", func(t *testing.T) {
+		dork = baidu.New().Site("a..com")
+
+		assert.True(errors.Is(dork.Validate(), query.ErrInvalidDomain), "it should be an invalid domain error")
+	})
+
+	t.Run("should reject excluded groups", func(t *testing.T) {
+		q := query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.cn"},
+			query.Not{Node: query.Group{Nodes: query.Query{query.Term{Text: "a"}, query.Or{}, query.Term{Text: "b"}}}},
+		}
+
+		err := baidu.FromQuery(q).Validate()
+
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+		assert.EqualError(err, "tag 1: invalid exclusion: groups can't be excluded")
+
+		_, err = baidu.Parse("site:example.cn -(a | b)")
+
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should round trip", func(t *testing.T) {
+		dork = baidu.New().
+			Site("example.cn").
+			Or().
+			InTitle("管理").
+			Not(baidu.New().FileType("pdf"))
+
+		result, err := baidu.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should render a query built for another engine", func(t *testing.T) {
+		q := googlesearch.New().
+			Site("example.com").
+			InTitle("admin").
+			Query()
+
+		assert.Equal("site:example.com intitle:\"admin\"", baidu.Render(q), "they should be equal")
+		assert.Equal("site:example.com intitle:\"admin\"", baidu.FromQuery(q).String(), "they should be equal")
+		assert.False(baidu.Supports(query.OpInText))
+	})
+}
@@ -0,0 +1,315 @@
+package bingsearch
+
+import (
+	"net/url"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "bing"
+
+const (
+	searchURL      = "https://www.bing.com/search"
+	siteTag        = "site:"
+	urlTag         = "url:"
+	inurlTag       = "inurl:"
+	filetypeTag    = "filetype:"
+	containsTag    = "contains:"
+	excludeTag     = "-"
+	intitleTag     = "intitle:"
+	inbodyTag      = "inbody:"
+	instreamsetTag = "instreamset:"
+	inanchorTag    = "inanchor:"
+	operatorOr     = "OR"
+	operatorAnd    = "AND"
+	ipTag          = "ip:"
+	locationTag    = "loc:"
+	languageTag    = "language:"
+	feedTag        = "feed:"
+	hasfeedTag     = "hasfeed:"
+)
+
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:        siteTag,
+		query.OpURL:         urlTag,
+		query.OpInURL:       inurlTag,
+		query.OpFileType:    filetypeTag,
+		query.OpContains:    containsTag,
+		query.OpInTitle:     intitleTag,
+		query.OpInText:      inbodyTag,
+		query.OpInStreamSet: instreamsetTag,
+		query.OpInAnchor:    inanchorTag,
+		query.OpIP:          ipTag,
+		query.OpRegion:      locationTag,
+		query.OpLanguage:    languageTag,
+		query.OpFeed:        feedTag,
+		query.OpHasFeed:     hasfeedTag,
+	},
+	And: operatorAnd,
+	Or:  operatorOr,
+	Not: excludeTag,
+}
+
+// BingSearch is the Bing search implementation for Dorkgen
+type BingSearch struct {
+	nodes   query.Query
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of BingSearch
+type Option func(*BingSearch)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *BingSearch) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *BingSearch) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of BingSearch
+func New(opts ...Option) *BingSearch {
+	e := &BingSearch{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of BingSearch from a query tree
+func FromQuery(q query.Query) *BingSearch {
+	return &BingSearch{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of BingSearch
+func Parse(dork string) (*BingSearch, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BingSearch{nodes: q}, nil
+}
+
+// Render converts a query tree to Bing Search syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in Bing Search
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Bing Search
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *BingSearch) add(n query.Node) *BingSearch {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *BingSearch) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *BingSearch) operator(name string, value string, quotes bool) *BingSearch {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *BingSearch) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *BingSearch) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *BingSearch) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+func (e *BingSearch) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return query.Validate(e.nodes)
+}
+
+// String converts all tags to a single request
+func (e *BingSearch) String() string {
+	return syntax.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values
+func (e *BingSearch) QueryValues() url.Values {
+	params := url.Values{}
+	params.Add("q", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded Bing Search URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *BingSearch) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded Bing Search URL, or returns an error if the base URL is invalid.
+func (e *BingSearch) URLE() (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, e.QueryValues())
+}
+
+// Site specifically searches that particular site and lists all the results for that site.
+func (e *BingSearch) Site(site string) *BingSearch {
+	return e.operator(query.OpSite, site, false)
+}
+
+// Or puts an OR operator in the request
+func (e *BingSearch) Or() *BingSearch {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request
+func (e *BingSearch) And() *BingSearch {
+	return e.add(query.And{})
+}
+
+// InBody searches for the occurrences of keywords in the body of the page.
+func (e *BingSearch) InBody(text string) *BingSearch {
+	return e.operator(query.OpInText, text, true)
+}
+
+// InTitle searches for occurrences of keywords in title all or one.
+func (e *BingSearch) InTitle(value string) *BingSearch {
+	return e.operator(query.OpInTitle, value, true)
+}
+
+// InAnchor searches link anchor text.
+func (e *BingSearch) InAnchor(text string) *BingSearch {
+	return e.operator(query.OpInAnchor, text, true)
+}
+
+// InStreamSet searches for keywords in a given set of streams, such as instreamset:(url title):value.
+func (e *BingSearch) InStreamSet(value string) *BingSearch {
+	return e.operator(query.OpInStreamSet, value, false)
+}
+
+// Contains keeps results with sites that contain links to the specified file type (i.e. wma).
+func (e *BingSearch) Contains(filetype string) *BingSearch {
+	return e.operator(query.OpContains, filetype, false)
+}
+
+// FileType searches for a particular filetype mentioned in the query.
+func (e *BingSearch) FileType(filetype string) *BingSearch {
+	return e.operator(query.OpFileType, filetype, true)
+}
+
+// IP finds sites that are hosted by a specific IP address.
+func (e *BingSearch) IP(ip string) *BingSearch {
+	return e.operator(query.OpIP, ip, false)
+}
+
+// Location returns webpages from a specific country or region.
+// An iso location code is a short code for a country for example, France is fr and USA is us.
+// https://en.wikipedia.org/wiki/ISO_3166-1
+func (e *BingSearch) Location(isoCode string) *BingSearch {
+	return e.operator(query.OpRegion, isoCode, false)
+}
+
+// Language returns webpages for a specific language.
+// See https://en.wikipedia.org/wiki/List_of_ISO_639-1_codes for a complete list of ISO 639-1 codes you can use.
+func (e *BingSearch) Language(lang string) *BingSearch {
+	return e.operator(query.OpLanguage, lang, false)
+}
+
+// Feed finds RSS or Atom feeds on a website for the terms you search for.
+func (e *BingSearch) Feed(feed string) *BingSearch {
+	return e.operator(query.OpFeed, feed, false)
+}
+
+// HasFeed finds webpages that contain an RSS or Atom feed on a website for the terms you search for.
+func (e *BingSearch) HasFeed(feed string) *BingSearch {
+	return e.operator(query.OpHasFeed, feed, false)
+}
+
+// InURL searches for a URL matching one of the keywords.
+func (e *BingSearch) InURL(url string) *BingSearch {
+	return e.operator(query.OpInURL, url, true)
+}
+
+// ExactURL checks whether the listed domain or web address is in the Bing index, using the url: operator.
+// It is not named URL to avoid conflicting with the URL conversion method.
+func (e *BingSearch) ExactURL(url string) *BingSearch {
+	return e.operator(query.OpURL, url, false)
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Bing Search supports excluding a group as a whole.
+func (e *BingSearch) Exclude(tags *BingSearch) *BingSearch {
+	nodes, err := query.Exclude(tags.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *BingSearch) Not(tag *BingSearch) *BingSearch {
+	n, err := query.Negate(tag.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *BingSearch) Group(tags *BingSearch) *BingSearch {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *BingSearch) Plain(value string) *BingSearch {
+	return e.add(query.Term{Text: value})
+}
@@ -0,0 +1,271 @@
+package bingsearch_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sundowndev/dorkgen/bingsearch"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"net/url"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+var dork *bingsearch.BingSearch
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal(result, "https://www.bing.com/search?q=site%3Aexample.com", "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal(result, "site:example.com", "they should be equal")
+	})
+
+	t.Run("should handle site tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Site("example.com").
+			String()
+
+		assert.Equal(result, "site:example.com", "they should be equal")
+	})
+
+	t.Run("should handle inbody tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			InBody("text").
+			String()
+
+		assert.Equal(result, "inbody:\"text\"", "they should be equal")
+	})
+
+	t.Run("should handle intitle tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			InTitle("admin").
+			String()
+
+		assert.Equal(result, "intitle:\"admin\"", "they should be equal")
+	})
+
+	t.Run("should handle inanchor tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			InAnchor("login").
+			String()
+
+		assert.Equal(result, "inanchor:\"login\"", "they should be equal")
+	})
+
+	t.Run("should handle instreamset tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			InStreamSet("(url title):admin").
+			String()
+
+		assert.Equal(result, "instreamset:(url title):admin", "they should be equal")
+	})
+
+	t.Run("should handle contains tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Contains("wma").
+			String()
+
+		assert.Equal(result, "contains:wma", "they should be equal")
+	})
+
+	t.Run("should handle filetype tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			FileType("pdf").
+			String()
+
+		assert.Equal(result, "filetype:\"pdf\"", "they should be equal")
+	})
+
+	t.Run("should handle ip tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			IP("93.184.216.34").
+			String()
+
+		assert.Equal(result, "ip:93.184.216.34", "they should be equal")
+	})
+
+	t.Run("should handle loc tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Location("fr").
+			String()
+
+		assert.Equal(result, "loc:fr", "they should be equal")
+	})
+
+	t.Run("should handle language tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Language("zh").
+			String()
+
+		assert.Equal(result, "language:zh", "they should be equal")
+	})
+
+	t.Run("should handle feed tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Feed("football").
+			String()
+
+		assert.Equal(result, "feed:football", "they should be equal")
+	})
+
+	t.Run("should handle hasfeed tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			HasFeed("football").
+			String()
+
+		assert.Equal(result, "hasfeed:football", "they should be equal")
+	})
+
+	t.Run("should handle url tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			ExactURL("example.com").
+			String()
+
+		assert.Equal(result, "url:example.com", "they should be equal")
+	})
+
+	t.Run("should handle inurl tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			InURL("login").
+			String()
+
+		assert.Equal(result, "inurl:\"login\"", "they should be equal")
+	})
+
+	t.Run("should sanitize quoted values", func(t *testing.T) {
+		dork = bingsearch.New().InTitle("say \"hi\"")
+
+		assert.Nil(dork.Err())
+		assert.Equal("intitle:\"say hi\"", dork.String(), "they should be equal")
+
+		dork = bingsearch.New(bingsearch.WithEscapePolicy(query.RejectQuotes)).InTitle("say \"hi\"")
+
+		assert.True(errors.Is(dork.Err(), query.ErrUnbalancedQuote), "it should be an unbalanced quote error")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = bingsearch.New(bingsearch.WithBaseURL("https://cn.bing.com/search?cc=cn")).Site("example.com")
+
+		assert.Equal("https://cn.bing.com/search?cc=cn&q=site%3Aexample.com", dork.URL(), "they should be equal")
+
+		_, err := bingsearch.New(bingsearch.WithBaseURL("/search")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+
+	t.Run("should handle exclude tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Exclude(bingsearch.New().Plain("html")).
+			Exclude(bingsearch.New().Plain("php")).
+			String()
+
+		assert.Equal(result, "-html -php", "they should be equal")
+	})
+
+	t.Run("should handle 'OR' tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Site("facebook.com").
+			Or().
+			Site("twitter.com").
+			String()
+
+		assert.Equal(result, "site:facebook.com OR site:twitter.com", "they should be equal")
+	})
+
+	t.Run("should handle 'AND' tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			InTitle("facebook").
+			And().
+			InTitle("twitter").
+			String()
+
+		assert.Equal(result, "intitle:\"facebook\" AND intitle:\"twitter\"", "they should be equal")
+	})
+
+	t.Run("should handle group tag correctly", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Site("linkedin.com").
+			Group(bingsearch.New().InBody("1").Or().InBody("2")).
+			InTitle("jordan").
+			String()
+
+		assert.Equal(result, "site:linkedin.com (inbody:\"1\" OR inbody:\"2\") intitle:\"jordan\"", "they should be equal")
+	})
+
+	t.Run("should return URL values", func(t *testing.T) {
+		dork = bingsearch.New()
+
+		result := dork.
+			Site("linkedin.com").
+			Group(bingsearch.New().InBody("1").Or().InBody("2")).
+			QueryValues()
+
+		assert.Equal(url.Values{
+			"q": []string{"site:linkedin.com (inbody:\"1\" OR inbody:\"2\")"},
+		}, result, "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should render a query built for another engine", func(t *testing.T) {
+		q := googlesearch.New().
+			Site("example.com").
+			InText("admin").
+			Query()
+
+		assert.Equal("site:example.com inbody:\"admin\"", bingsearch.Render(q), "they should be equal")
+		assert.Equal("site:example.com inbody:\"admin\"", bingsearch.FromQuery(q).String(), "they should be equal")
+	})
+}
@@ -0,0 +1,266 @@
+package bravesearch
+
+import (
+	"net/url"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "brave"
+
+const (
+	searchURL   = "https://search.brave.com/search"
+	siteTag     = "site:"
+	filetypeTag = "filetype:"
+	intitleTag  = "intitle:"
+	inbodyTag   = "inbody:"
+	inpageTag   = "inpage:"
+	langTag     = "lang:"
+	locationTag = "loc:"
+	excludeTag  = "-"
+	operatorOr  = "OR"
+	operatorAnd = "AND"
+)
+
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:     siteTag,
+		query.OpFileType: filetypeTag,
+		query.OpInTitle:  intitleTag,
+		query.OpInText:   inbodyTag,
+		query.OpInPage:   inpageTag,
+		query.OpLanguage: langTag,
+		query.OpRegion:   locationTag,
+	},
+	And: operatorAnd,
+	Or:  operatorOr,
+	Not: excludeTag,
+}
+
+// BraveSearch is the Brave Search implementation for Dorkgen
+type BraveSearch struct {
+	nodes   query.Query
+	params  url.Values
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of BraveSearch
+type Option func(*BraveSearch)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *BraveSearch) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *BraveSearch) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of BraveSearch
+func New(opts ...Option) *BraveSearch {
+	e := &BraveSearch{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of BraveSearch from a query tree
+func FromQuery(q query.Query) *BraveSearch {
+	return &BraveSearch{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of BraveSearch
+func Parse(dork string) (*BraveSearch, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BraveSearch{nodes: q}, nil
+}
+
+// Render converts a query tree to Brave Search syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in Brave Search
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Brave Search
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *BraveSearch) add(n query.Node) *BraveSearch {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *BraveSearch) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *BraveSearch) operator(name string, value string, quotes bool) *BraveSearch {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *BraveSearch) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *BraveSearch) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *BraveSearch) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+func (e *BraveSearch) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return query.Validate(e.nodes)
+}
+
+// String converts all tags to a single request
+func (e *BraveSearch) String() string {
+	return syntax.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values, including URL parameters
+func (e *BraveSearch) QueryValues() url.Values {
+	params := url.Values{}
+	for k, v := range e.params {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("q", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded Brave Search URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *BraveSearch) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded Brave Search URL, or returns an error if the base URL is invalid.
+func (e *BraveSearch) URLE() (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, e.QueryValues())
+}
+
+// Site searches pages of the given site only.
+func (e *BraveSearch) Site(site string) *BraveSearch {
+	return e.operator(query.OpSite, site, false)
+}
+
+// FileType searches for documents of the given type, such as "pdf".
+func (e *BraveSearch) FileType(filetype string) *BraveSearch {
+	return e.operator(query.OpFileType, filetype, false)
+}
+
+// InTitle searches for pages with the keywords in their title.
+func (e *BraveSearch) InTitle(title string) *BraveSearch {
+	return e.operator(query.OpInTitle, title, true)
+}
+
+// InBody searches for pages with the keywords in their body.
+func (e *BraveSearch) InBody(text string) *BraveSearch {
+	return e.operator(query.OpInText, text, true)
+}
+
+// InPage searches for pages with the keywords either in their title or in their body.
+func (e *BraveSearch) InPage(text string) *BraveSearch {
+	return e.operator(query.OpInPage, text, true)
+}
+
+// Lang searches for pages in the given language, using its ISO 639-1 code such as "es".
+func (e *BraveSearch) Lang(lang string) *BraveSearch {
+	return e.operator(query.OpLanguage, lang, false)
+}
+
+// Loc searches for pages from the given country, using its ISO 3166-1 alpha-2 code such as "gb".
+func (e *BraveSearch) Loc(country string) *BraveSearch {
+	return e.operator(query.OpRegion, country, false)
+}
+
+// Or puts an OR operator in the request
+func (e *BraveSearch) Or() *BraveSearch {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request
+func (e *BraveSearch) And() *BraveSearch {
+	return e.add(query.And{})
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Groups can't be excluded as a whole.
+func (e *BraveSearch) Exclude(tags *BraveSearch) *BraveSearch {
+	nodes, err := query.Exclude(tags.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *BraveSearch) Not(tag *BraveSearch) *BraveSearch {
+	n, err := query.Negate(tag.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *BraveSearch) Group(tags *BraveSearch) *BraveSearch {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *BraveSearch) Plain(value string) *BraveSearch {
+	return e.add(query.Term{Text: value})
+}
@@ -0,0 +1,125 @@
+package bravesearch_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/bravesearch"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+var dork *bravesearch.BraveSearch
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = bravesearch.New()
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal("https://search.brave.com/search?q=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = bravesearch.New()
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal("site:example.com", result, "they should be equal")
+	})
+
+	t.Run("should handle operators correctly", func(t *testing.T) {
+		dork = bravesearch.New()
+
+		result := dork.
+			Site("example.com").
+			FileType("pdf").
+			InTitle("report").
+			InBody("confidential").
+			InPage("password").
+			Lang("es").
+			Loc("gb").
+			String()
+
+		assert.Equal("site:example.com filetype:pdf intitle:\"report\" inbody:\"confidential\" inpage:\"password\" lang:es loc:gb", result, "they should be equal")
+	})
+
+	t.Run("should handle or, and and groups correctly", func(t *testing.T) {
+		dork = bravesearch.New()
+
+		result := dork.
+			Group(bravesearch.New().Site("a.com").Or().Site("b.com")).
+			And().
+			InTitle("admin").
+			String()
+
+		assert.Equal("(site:a.com OR site:b.com) AND intitle:\"admin\"", result, "they should be equal")
+	})
+
+	t.Run("should handle exclusions correctly", func(t *testing.T) {
+		dork = bravesearch.New()
+
+		result := dork.
+			Site("example.com").
+			Exclude(bravesearch.New().FileType("pdf").Lang("en")).
+			String()
+
+		assert.Equal("site:example.com -filetype:pdf -lang:en", result, "they should be equal")
+		assert.True(errors.Is(bravesearch.New().Not(bravesearch.New().Group(bravesearch.New().Site("a.com"))).Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = bravesearch.New(bravesearch.WithBaseURL("http://localhost:8080/search?source=web")).Site("example.com")
+
+		assert.Equal("http://localhost:8080/search?q=site%3Aexample.com&source=web", dork.URL(), "they should be equal")
+
+		_, err := bravesearch.New(bravesearch.WithBaseURL("search")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+
+	t.Run("should validate the request", func(t *testing.T) {
+		dork = bravesearch.New().InTitle("a").Or().Or()
+
+		assert.NotNil(dork.Validate())
+		assert.Nil(bravesearch.New().Site("example.com").Validate())
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should round trip", func(t *testing.T) {
+		dork = bravesearch.New().
+			Site("example.com").
+			Or().
+			InPage("admin").
+			Not(bravesearch.New().Loc("us"))
+
+		result, err := bravesearch.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should render a query built for another engine", func(t *testing.T) {
+		q := googlesearch.New().
+			Site("example.com").
+			InText("admin").
+			Query()
+
+		assert.Equal("site:example.com inbody:\"admin\"", bravesearch.Render(q), "they should be equal")
+		assert.Equal("site:example.com inbody:\"admin\"", bravesearch.FromQuery(q).String(), "they should be equal")
+		assert.True(bravesearch.Supports(query.OpInPage))
+		assert.False(bravesearch.Supports(query.OpInURL))
+	})
+}
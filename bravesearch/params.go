@@ -0,0 +1,56 @@
+package bravesearch
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// Freshness restricts results to a recent period, used as the "tf" URL parameter.
+type Freshness string
+
+// Freshness periods
+const (
+	PastDay   Freshness = "pd"
+	PastWeek  Freshness = "pw"
+	PastMonth Freshness = "pm"
+	PastYear  Freshness = "py"
+)
+
+// maxOffset is the last result page Brave Search serves.
+const maxOffset = 9
+
+func (e *BraveSearch) set(param string, value string) *BraveSearch {
+	if e.params == nil {
+		e.params = url.Values{}
+	}
+	e.params.Set(param, value)
+	return e
+}
+
+func (e *BraveSearch) invalid(param string, value interface{}) *BraveSearch {
+	if e.err == nil {
+		e.err = fmt.Errorf("%w: %s=%v", query.ErrInvalidParameter, param, value)
+	}
+	return e
+}
+
+// Freshness restricts results to a recent period (tf parameter).
+func (e *BraveSearch) Freshness(f Freshness) *BraveSearch {
+	switch f {
+	case PastDay, PastWeek, PastMonth, PastYear:
+		return e.set("tf", string(f))
+	}
+	return e.invalid("tf", f)
+}
+
+// Offset sets the result page to show, starting at 0 for the first page (offset parameter).
+// Brave Search serves at most 10 pages.
+func (e *BraveSearch) Offset(page int) *BraveSearch {
+	if page < 0 || page > maxOffset {
+		return e.invalid("offset", page)
+	}
+	return e.set("offset", strconv.Itoa(page))
+}
@@ -0,0 +1,31 @@
+package bravesearch_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/bravesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestParams(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should set the freshness and offset", func(t *testing.T) {
+		dork = bravesearch.New().Site("example.com").Freshness(bravesearch.PastWeek).Offset(2)
+
+		assert.Nil(dork.Err())
+		assert.Equal("https://search.brave.com/search?offset=2&q=site%3Aexample.com&tf=pw", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		dork = bravesearch.New().Freshness("pd7")
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidParameter), "it should be an invalid parameter error")
+		assert.EqualError(dork.Err(), "invalid parameter: tf=pd7")
+
+		assert.EqualError(bravesearch.New().Offset(10).Err(), "invalid parameter: offset=10")
+		assert.EqualError(bravesearch.New().Offset(-1).Err(), "invalid parameter: offset=-1")
+	})
+}
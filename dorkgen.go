@@ -1,16 +1,64 @@
 package dorkgen
 
 import (
+	"github.com/sundowndev/dorkgen/baidu"
+	"github.com/sundowndev/dorkgen/bingsearch"
+	"github.com/sundowndev/dorkgen/bravesearch"
 	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/githubsearch"
 	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/searxng"
+	"github.com/sundowndev/dorkgen/startpage"
+	"github.com/sundowndev/dorkgen/yahoosearch"
+	"github.com/sundowndev/dorkgen/yandex"
 )
 
 // NewGoogleSearch returns a new instance of GoogleSearch
-func NewGoogleSearch() *googlesearch.GoogleSearch {
-	return googlesearch.New()
+func NewGoogleSearch(opts ...googlesearch.Option) *googlesearch.GoogleSearch {
+	return googlesearch.New(opts...)
 }
 
 // NewDuckDuckGo returns a new instance of DuckDuckGo
-func NewDuckDuckGo() *duckduckgo.DuckDuckGo {
-	return duckduckgo.New()
+func NewDuckDuckGo(opts ...duckduckgo.Option) *duckduckgo.DuckDuckGo {
+	return duckduckgo.New(opts...)
+}
+
+// NewBingSearch returns a new instance of BingSearch
+func NewBingSearch(opts ...bingsearch.Option) *bingsearch.BingSearch {
+	return bingsearch.New(opts...)
+}
+
+// NewYahooSearch returns a new instance of YahooSearch
+func NewYahooSearch(opts ...yahoosearch.Option) *yahoosearch.YahooSearch {
+	return yahoosearch.New(opts...)
+}
+
+// NewYandex returns a new instance of Yandex
+func NewYandex(opts ...yandex.Option) *yandex.Yandex {
+	return yandex.New(opts...)
+}
+
+// NewBaidu returns a new instance of Baidu
+func NewBaidu(opts ...baidu.Option) *baidu.Baidu {
+	return baidu.New(opts...)
+}
+
+// NewBraveSearch returns a new instance of BraveSearch
+func NewBraveSearch(opts ...bravesearch.Option) *bravesearch.BraveSearch {
+	return bravesearch.New(opts...)
+}
+
+// NewStartpage returns a new instance of Startpage
+func NewStartpage(opts ...startpage.Option) *startpage.Startpage {
+	return startpage.New(opts...)
+}
+
+// NewSearXNG returns a new instance of SearXNG sending requests to the given SearXNG instance
+func NewSearXNG(instance string, opts ...searxng.Option) *searxng.SearXNG {
+	return searxng.New(instance, opts...)
+}
+
+// NewGitHubSearch returns a new instance of GitHubSearch
+func NewGitHubSearch(opts ...githubsearch.Option) *githubsearch.GitHubSearch {
+	return githubsearch.New(opts...)
 }
@@ -2,8 +2,16 @@ package dorkgen
 
 import (
 	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/baidu"
+	"github.com/sundowndev/dorkgen/bingsearch"
+	"github.com/sundowndev/dorkgen/bravesearch"
 	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/githubsearch"
 	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/searxng"
+	"github.com/sundowndev/dorkgen/startpage"
+	"github.com/sundowndev/dorkgen/yahoosearch"
+	"github.com/sundowndev/dorkgen/yandex"
 	"testing"
 )
 
@@ -21,4 +29,52 @@ func TestInit(t *testing.T) {
 
 		assert.IsType(&duckduckgo.DuckDuckGo{}, dork, "they should be equal")
 	})
+
+	t.Run("should create a BingSearch instance", func(t *testing.T) {
+		dork := NewBingSearch()
+
+		assert.IsType(&bingsearch.BingSearch{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a YahooSearch instance", func(t *testing.T) {
+		dork := NewYahooSearch()
+
+		assert.IsType(&yahoosearch.YahooSearch{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a Yandex instance", func(t *testing.T) {
+		dork := NewYandex()
+
+		assert.IsType(&yandex.Yandex{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a Baidu instance", func(t *testing.T) {
+		dork := NewBaidu()
+
+		assert.IsType(&baidu.Baidu{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a BraveSearch instance", func(t *testing.T) {
+		dork := NewBraveSearch()
+
+		assert.IsType(&bravesearch.BraveSearch{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a Startpage instance", func(t *testing.T) {
+		dork := NewStartpage()
+
+		assert.IsType(&startpage.Startpage{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a SearXNG instance", func(t *testing.T) {
+		dork := NewSearXNG("http://localhost:8888")
+
+		assert.IsType(&searxng.SearXNG{}, dork, "they should be equal")
+	})
+
+	t.Run("should create a GitHubSearch instance", func(t *testing.T) {
+		dork := NewGitHubSearch()
+
+		assert.IsType(&githubsearch.GitHubSearch{}, dork, "they should be equal")
+	})
 }
@@ -3,8 +3,14 @@ package duckduckgo
 import (
 	"net/url"
 	"strings"
+	"sync"
+
+	"github.com/sundowndev/dorkgen/query"
 )
 
+// EngineName is the name identifying this search engine.
+const EngineName = "duckduckgo"
+
 const (
 	searchURL     = "https://duckduckgo.com/"
 	siteTag       = "site:"
@@ -24,149 +30,376 @@ const (
 	allintitleTag = "allintitle:"
 )
 
-// DuckDuckGo is the Google search implementation for Dorkgen
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:       siteTag,
+		query.OpInURL:      urlTag,
+		query.OpFileType:   filetypeTag,
+		query.OpExt:        extTag,
+		query.OpInTitle:    intitleTag,
+		query.OpInText:     intextTag,
+		query.OpAllInURL:   allInURLTag,
+		query.OpRegion:     locationTag,
+		query.OpFeed:       feedTag,
+		query.OpHasFeed:    hasfeedTag,
+		query.OpLanguage:   languageTag,
+		query.OpAllInTitle: allintitleTag,
+	},
+	And: operatorAnd,
+	Or:  operatorOr,
+	Not: excludeTag,
+}
+
+// DuckDuckGo is the Google search implementation for Dorkgen.
+// It is safe for concurrent use, although tags added concurrently are appended in no particular order.
 type DuckDuckGo struct {
-	tags []string
+	// mu guards nodes, params, bang and err, other fields are only set by options
+	mu      sync.RWMutex
+	nodes   query.Query
+	params  url.Values
+	bang    string
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+	// immutable makes every method return a modified copy instead of modifying the receiver
+	immutable bool
+}
+
+// Option configures an instance of DuckDuckGo
+type Option func(*DuckDuckGo)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *DuckDuckGo) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *DuckDuckGo) {
+		e.baseURL = baseURL
+	}
+}
+
+// WithImmutable makes every method return a modified copy of the instance instead of modifying it,
+// so a base dork can be shared and extended in several ways.
+func WithImmutable() Option {
+	return func(e *DuckDuckGo) {
+		e.immutable = true
+	}
 }
 
 // New creates a new instance of DuckDuckGo
-func New() *DuckDuckGo {
-	return &DuckDuckGo{}
+func New(opts ...Option) *DuckDuckGo {
+	e := &DuckDuckGo{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
 }
 
-func (e *DuckDuckGo) join(tag string, value string, quotes bool) string {
-	if quotes {
-		return tag + "\"" + value + "\""
+// FromQuery creates a new instance of DuckDuckGo from a query tree
+func FromQuery(q query.Query) *DuckDuckGo {
+	return &DuckDuckGo{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of DuckDuckGo.
+// A leading !bang is recognized and set as the bang of the request, other words starting with ! are kept as terms.
+// Excluded groups are rejected, since DuckDuckGo doesn't support them.
+func Parse(dork string) (*DuckDuckGo, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
 	}
 
-	return tag + value
+	if err := query.ValidateExclusions(q); err != nil {
+		return nil, err
+	}
+
+	e := &DuckDuckGo{nodes: q}
+	if len(q) > 0 {
+		if t, ok := q[0].(query.Term); ok && len(t.Text) > 1 && t.Text[0] == '!' && isBang(t.Text[1:]) {
+			e.nodes = q[1:]
+			e.bang = t.Text[1:]
+		}
+	}
+
+	return e, nil
 }
 
-// String converts all tags to a single request
+// Render converts a query tree to DuckDuckGo syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in DuckDuckGo
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in DuckDuckGo
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+// Clone returns a deep copy of the instance, which can be modified without affecting the original.
+func (e *DuckDuckGo) Clone() *DuckDuckGo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	c := &DuckDuckGo{
+		nodes:     e.nodes.Copy(),
+		bang:      e.bang,
+		err:       e.err,
+		escape:    e.escape,
+		baseURL:   e.baseURL,
+		immutable: e.immutable,
+	}
+	if e.params != nil {
+		c.params = make(url.Values, len(e.params))
+		for k, v := range e.params {
+			c.params[k] = append([]string(nil), v...)
+		}
+	}
+
+	return c
+}
+
+// next returns the instance to modify: a copy in immutable mode, the instance itself otherwise.
+func (e *DuckDuckGo) next() *DuckDuckGo {
+	if e.immutable {
+		return e.Clone()
+	}
+
+	return e
+}
+
+func (e *DuckDuckGo) add(n query.Node) *DuckDuckGo {
+	return e.next().push(nil, n)
+}
+
+// push records the error, if any, and appends nodes to the instance itself, even in immutable mode.
+func (e *DuckDuckGo) push(err error, nodes ...query.Node) *DuckDuckGo {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.fail(err)
+	}
+	for _, n := range nodes {
+		if err := query.ValidateNext(e.nodes, n); err != nil {
+			e.fail(err)
+		}
+		e.nodes = append(e.nodes, n)
+	}
+
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+// The caller must hold the lock.
+func (e *DuckDuckGo) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *DuckDuckGo) operator(name string, value string, quotes bool) *DuckDuckGo {
+	value, err := query.Sanitize(value, quotes, e.escape)
+
+	return e.next().push(err, query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *DuckDuckGo) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *DuckDuckGo) Query() query.Query {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *DuckDuckGo) Err() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+// DuckDuckGo doesn't support excluding a group as a whole.
+func (e *DuckDuckGo) Validate() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := query.Validate(e.nodes); err != nil {
+		return err
+	}
+
+	return query.ValidateExclusions(e.nodes)
+}
+
+// String converts all tags to a single request, prefixed by the !bang if any
 func (e *DuckDuckGo) String() string {
-	return strings.Join(e.tags, " ")
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.render()
+}
+
+// render converts all tags to a single request. The caller must hold the lock.
+func (e *DuckDuckGo) render() string {
+	if e.bang != "" {
+		return strings.TrimSpace("!" + e.bang + " " + syntax.Render(e.nodes))
+	}
+
+	return syntax.Render(e.nodes)
 }
 
-// QueryValues returns search request as URL values
+// QueryValues returns search request as URL values, including URL parameters
 func (e *DuckDuckGo) QueryValues() url.Values {
-	tags := strings.Join(e.tags, " ")
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 
 	params := url.Values{}
-	params.Add("q", tags)
+	for k, v := range e.params {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("q", e.render())
 
 	return params
 }
 
-// URL converts tags to an encoded Google Search URL
+// URL converts tags to an encoded DuckDuckGo URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
 func (e *DuckDuckGo) URL() string {
-	baseURL, _ := url.Parse(searchURL)
+	u, _ := e.encode(e.QueryValues())
+	return u
+}
 
-	baseURL.RawQuery = e.QueryValues().Encode()
+// URLE converts tags to an encoded DuckDuckGo URL, or returns an error if the base URL is invalid.
+func (e *DuckDuckGo) URLE() (string, error) {
+	return e.encode(e.QueryValues())
+}
 
-	return baseURL.String()
+func (e *DuckDuckGo) encode(params url.Values) (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, params)
 }
 
 // Site specifically searches that particular site and lists all the results for that site.
 func (e *DuckDuckGo) Site(site string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(siteTag, site, false))
-	return e
+	return e.operator(query.OpSite, site, false)
 }
 
 // Or puts an OR operator in the request
 func (e *DuckDuckGo) Or() *DuckDuckGo {
-	e.tags = append(e.tags, operatorOr)
-	return e
+	return e.add(query.Or{})
 }
 
 // And puts an AND operator in the request
 func (e *DuckDuckGo) And() *DuckDuckGo {
-	e.tags = append(e.tags, operatorAnd)
-	return e
+	return e.add(query.And{})
 }
 
 // InText searches for the occurrences of keywords all at once or one at a time.
 func (e *DuckDuckGo) InText(text string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(intextTag, text, true))
-	return e
+	return e.operator(query.OpInText, text, true)
 }
 
 // InURL searches for a URL matching one of the keywords.
 func (e *DuckDuckGo) InURL(url string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(urlTag, url, true))
-	return e
+	return e.operator(query.OpInURL, url, true)
 }
 
 // FileType searches for a particular filetype mentioned in the query.
 func (e *DuckDuckGo) FileType(filetype string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(filetypeTag, filetype, true))
-	return e
+	return e.operator(query.OpFileType, filetype, true)
 }
 
 // Ext searches for a particular file extension mentioned in the query.
 func (e *DuckDuckGo) Ext(ext string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(extTag, ext, false))
-	return e
+	return e.operator(query.OpExt, ext, false)
 }
 
-// Exclude excludes some results.
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// DuckDuckGo doesn't support excluding groups, which report an error.
 func (e *DuckDuckGo) Exclude(tags *DuckDuckGo) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(excludeTag, tags.String(), false))
-	return e
+	nodes, err := query.Exclude(tags.Query(), false)
+	if err != nil {
+		return e.next().push(err)
+	}
+
+	return e.next().push(nil, nodes...)
+}
+
+// Not excludes results matching a single tag.
+func (e *DuckDuckGo) Not(tag *DuckDuckGo) *DuckDuckGo {
+	n, err := query.Negate(tag.Query(), false)
+	if err != nil {
+		return e.next().push(err)
+	}
+
+	return e.next().push(nil, n)
 }
 
 // Group isolate tags between parentheses
 func (e *DuckDuckGo) Group(tags *DuckDuckGo) *DuckDuckGo {
-	e.tags = append(e.tags, "("+tags.String()+")")
-	return e
+	return e.add(query.Group{Nodes: tags.Query()})
 }
 
 // InTitle searches for occurrences of keywords in title all or one.
 func (e *DuckDuckGo) InTitle(value string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(intitleTag, value, true))
-	return e
+	return e.operator(query.OpInTitle, value, true)
 }
 
 // Plain allows you to add additional values as string without any kind of formatting.
 func (e *DuckDuckGo) Plain(value string) *DuckDuckGo {
-	e.tags = append(e.tags, value)
-	return e
+	return e.add(query.Term{Text: value})
 }
 
 // AllInURL finds pages that include a specific keyword as part of their indexed URLs.
 func (e *DuckDuckGo) AllInURL(value string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(allInURLTag, value, true))
-	return e
+	return e.operator(query.OpAllInURL, value, true)
 }
 
 // Location searches for specific region.
 // An iso location code is a short code for a country for example, Egypt is eg and USA is us.
 // https://en.wikipedia.org/wiki/ISO_3166-1
 func (e *DuckDuckGo) Location(isoCode string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(locationTag, isoCode, true))
-	return e
+	return e.operator(query.OpRegion, isoCode, true)
 }
 
 // Feed finds RSS feed related to search term (i.e. rss).
 func (e *DuckDuckGo) Feed(feed string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(feedTag, feed, false))
-	return e
+	return e.operator(query.OpFeed, feed, false)
 }
 
 // HasFeed finds webpages that contain both the term or terms for which you are querying and one or more RSS or Atom feeds.
 func (e *DuckDuckGo) HasFeed(url string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(hasfeedTag, url, true))
-	return e
+	return e.operator(query.OpHasFeed, url, true)
 }
 
 // Language returns websites that match the search term in a specified language.
 // See https://en.wikipedia.org/wiki/List_of_ISO_639-1_codes for a complete list of ISO 639-1 codes you can use.
 func (e *DuckDuckGo) Language(lang string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(languageTag, lang, false))
-	return e
+	return e.operator(query.OpLanguage, lang, false)
 }
 
 // AllInTitle finds pages that include a specific keyword as part of the indexed title tag.
 func (e *DuckDuckGo) AllInTitle(value string) *DuckDuckGo {
-	e.tags = append(e.tags, e.join(allintitleTag, value, true))
-	return e
+	return e.operator(query.OpAllInTitle, value, true)
 }
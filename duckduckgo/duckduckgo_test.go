@@ -1,9 +1,13 @@
 package duckduckgo_test
 
 import (
+	"errors"
 	"fmt"
 	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/query"
 	"net/url"
+	"strconv"
+	"sync"
 	"testing"
 
 	assertion "github.com/stretchr/testify/assert"
@@ -216,3 +220,287 @@ func TestInit(t *testing.T) {
 		}, result, "they should be equal")
 	})
 }
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should parse a dork string", func(t *testing.T) {
+		result, err := duckduckgo.Parse(`site:example.com region:"fr" -feed:rss (language:zh | "a b")`)
+
+		assert.Nil(err)
+		assert.Equal(duckduckgo.New().
+			Site("example.com").
+			Location("fr").
+			Exclude(duckduckgo.New().Feed("rss")).
+			Group(duckduckgo.New().Language("zh").Or().Plain("\"a b\"")).
+			String(), result.String(), "they should be equal")
+	})
+
+	t.Run("should be stable when round tripping", func(t *testing.T) {
+		input := `site:a.com OR  allintitle:"x" + -html ext:(doc | pdf)`
+
+		result, err := duckduckgo.Parse(input)
+		assert.Nil(err)
+
+		again, err := duckduckgo.Parse(result.String())
+		assert.Nil(err)
+		assert.Equal(`site:a.com | allintitle:"x" + -html ext:(doc | pdf)`, result.String(), "they should be equal")
+		assert.Equal(result.String(), again.String(), "they should be equal")
+	})
+
+	t.Run("should return syntax errors", func(t *testing.T) {
+		_, err := duckduckgo.Parse(`site:a.com)`)
+
+		assert.EqualError(err, "syntax error at offset 10: unexpected closing parenthesis")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should accept a valid request", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Site("example.com").
+			Or().
+			Site("example.org").
+			FileType("pdf")
+
+		assert.Nil(dork.Err())
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should accumulate errors in the fluent chain", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Site("example.com").
+			FileType(".pdf")
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidExtension), "it should be an invalid extension error")
+		assert.EqualError(dork.Err(), "tag 1: invalid file extension: \".pdf\"")
+	})
+
+	t.Run("should reject a dangling operator", func(t *testing.T) {
+		dork = duckduckgo.New().Or().Site("example.com")
+
+		assert.True(errors.Is(dork.Validate(), query.ErrDanglingOperator), "it should be a dangling operator error")
+	})
+
+	t.Run("should reject whitespaces in unquoted values", func(t *testing.T) {
+		dork = duckduckgo.New().Language("en site:evil.com")
+
+		assert.True(errors.Is(dork.Validate(), query.ErrUnquotedSpace), "it should be an unquoted space error")
+	})
+
+	t.Run("should reject unbalanced quotes", func(t *testing.T) {
+		dork = duckduckgo.New().Plain("\"admin")
+
+		assert.True(errors.Is(dork.Validate(), query.ErrUnbalancedQuote), "it should be an unbalanced quote error")
+	})
+}
+
+func TestEscaping(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should strip embedded quotes by default", func(t *testing.T) {
+		dork = duckduckgo.New().InTitle(`“admin” "panel"`)
+
+		assert.Equal(`intitle:"admin panel"`, dork.String(), "they should be equal")
+	})
+
+	t.Run("should escape embedded quotes", func(t *testing.T) {
+		dork = duckduckgo.New(duckduckgo.WithEscapePolicy(query.EscapeQuotes)).InText("say\r\n\"hi\"")
+
+		assert.Equal(`intext:"say \"hi\""`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should reject embedded quotes", func(t *testing.T) {
+		dork = duckduckgo.New(duckduckgo.WithEscapePolicy(query.RejectQuotes)).InText(`say "hi"`)
+
+		assert.True(errors.Is(dork.Err(), query.ErrUnbalancedQuote), "it should be an unbalanced quote error")
+	})
+
+	t.Run("should keep operator-like values inside quotes", func(t *testing.T) {
+		dork = duckduckgo.New().InURL("site:example.com | x")
+
+		assert.Equal(`inurl:"site:example.com | x"`, dork.String(), "they should be equal")
+	})
+}
+
+func TestExclude(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should negate each tag of a compound expression", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Exclude(duckduckgo.New().Site("a.com").Or().Site("b.com"))
+
+		assert.Equal("-site:a.com -site:b.com", dork.String(), "they should be equal")
+	})
+
+	t.Run("should reject groups", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Site("example.com").
+			Exclude(duckduckgo.New().Group(duckduckgo.New().Plain("php").Or().Plain("html")))
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+		assert.EqualError(dork.Err(), "tag 1: invalid exclusion: groups can't be excluded")
+		assert.Equal("site:example.com", dork.String(), "they should be equal")
+	})
+
+	t.Run("should reject excluded groups built elsewhere", func(t *testing.T) {
+		q := query.Query{
+			query.Not{Node: query.Group{Nodes: query.Query{query.Term{Text: "php"}, query.Or{}, query.Term{Text: "html"}}}},
+			query.Operator{Name: query.OpInText, Value: "x", Quoted: true},
+		}
+
+		err := duckduckgo.FromQuery(q).Validate()
+
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+		assert.EqualError(err, "tag 0: invalid exclusion: groups can't be excluded")
+
+		_, err = duckduckgo.Parse(`-(php | html) intext:"x"`)
+
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+
+	t.Run("should negate a single tag", func(t *testing.T) {
+		dork = duckduckgo.New().Not(duckduckgo.New().Feed("rss"))
+
+		assert.Equal("-feed:rss", dork.String(), "they should be equal")
+	})
+}
+
+func TestBaseURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = duckduckgo.New(duckduckgo.WithBaseURL("https://html.duckduckgo.com/html/")).Site("example.com")
+
+		result, err := dork.URLE()
+
+		assert.Nil(err)
+		assert.Equal("https://html.duckduckgo.com/html/?q=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should return an error for an invalid base URL", func(t *testing.T) {
+		dork = duckduckgo.New(duckduckgo.WithBaseURL("duckduckgo.com")).Site("example.com")
+
+		_, err := dork.URLE()
+
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+}
+
+func TestClone(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should clone the request", func(t *testing.T) {
+		base := duckduckgo.New().Site("example.com")
+		a := base.Clone().InText("a")
+		b := base.Clone().InText("b")
+
+		assert.Equal("site:example.com", base.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"a\"", a.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"b\"", b.String(), "they should be equal")
+	})
+
+	t.Run("should clone URL parameters and errors", func(t *testing.T) {
+		base := duckduckgo.New().Site("a..com").SafeSearch(duckduckgo.SafeSearchStrict)
+		clone := base.Clone().SafeSearch(duckduckgo.SafeSearchOff)
+
+		assert.Equal(base.Err(), clone.Err(), "they should be equal")
+		assert.NotEqual(base.URL(), clone.URL(), "they should not be equal")
+	})
+
+	t.Run("should branch immutable requests", func(t *testing.T) {
+		base := duckduckgo.New(duckduckgo.WithImmutable()).Site("example.com")
+		a := base.InText("a").Or().InText("c")
+		b := base.InText("b").Exclude(duckduckgo.New().InURL("login"))
+		c := base.Site("a..com")
+
+		assert.Equal("site:example.com", base.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"a\" | intext:\"c\"", a.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"b\" -inurl:\"login\"", b.String(), "they should be equal")
+		assert.Nil(base.Err())
+		assert.True(errors.Is(c.Err(), query.ErrInvalidDomain), "it should be an invalid domain error")
+	})
+
+	t.Run("should branch immutable URL parameters", func(t *testing.T) {
+		base := duckduckgo.New(duckduckgo.WithImmutable()).Site("example.com")
+		a := base.SafeSearch(duckduckgo.SafeSearchOff)
+
+		assert.Equal("https://duckduckgo.com/?q=site%3Aexample.com", base.URL(), "they should be equal")
+		assert.NotEqual(base.URL(), a.URL(), "they should not be equal")
+	})
+}
+
+func TestConcurrency(t *testing.T) {
+	assert := assertion.New(t)
+
+	const workers = 50
+
+	t.Run("should read a shared request concurrently", func(t *testing.T) {
+		base := duckduckgo.New().Site("example.com").InText("admin").SafeSearch(duckduckgo.SafeSearchStrict)
+		expected := base.URL()
+
+		var wg sync.WaitGroup
+		results := make([]string, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_ = base.String()
+				_ = base.QueryValues()
+				_ = base.Validate()
+				results[i] = base.URL()
+			}(i)
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			assert.Equal(expected, result, "they should be equal")
+		}
+	})
+
+	t.Run("should add tags to a shared request concurrently", func(t *testing.T) {
+		shared := duckduckgo.New().Site("example.com")
+
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				shared.InText(strconv.Itoa(i)).SafeSearch(duckduckgo.SafeSearchStrict)
+			}(i)
+			go func() {
+				defer wg.Done()
+				_ = shared.URL()
+				_ = shared.Query()
+				_ = shared.Err()
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(shared.Query(), workers+1)
+		assert.Nil(shared.Validate())
+	})
+
+	t.Run("should branch a shared immutable request concurrently", func(t *testing.T) {
+		base := duckduckgo.New(duckduckgo.WithImmutable()).Site("example.com")
+
+		var wg sync.WaitGroup
+		results := make([]string, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = base.InText(strconv.Itoa(i)).Clone().Or().InURL("login").String()
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal("site:example.com", base.String(), "they should be equal")
+		for i, result := range results {
+			assert.Equal(fmt.Sprintf("site:example.com intext:\"%d\" | inurl:\"login\"", i), result, "they should be equal")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package duckduckgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// Document returns the request as a document, which can be saved as JSON or YAML.
+func (e *DuckDuckGo) Document() query.Document {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	doc := query.Document{Engine: EngineName, Tags: query.Encode(e.nodes), Bang: e.bang}
+	if len(e.params) > 0 {
+		doc.Params = url.Values{}
+		for k, v := range e.params {
+			doc.Params[k] = append([]string(nil), v...)
+		}
+	}
+
+	return doc
+}
+
+// FromDocument creates a new instance of DuckDuckGo from a document saved as JSON or YAML.
+func FromDocument(doc query.Document, opts ...Option) (*DuckDuckGo, error) {
+	e := New(opts...)
+	if err := e.load(doc); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// load replaces the tags, URL parameters and bang of the instance with the ones of the document.
+func (e *DuckDuckGo) load(doc query.Document) error {
+	if doc.Engine != EngineName {
+		return fmt.Errorf("%w: %q", query.ErrEngineMismatch, doc.Engine)
+	}
+	if doc.Bang != "" && !isBang(doc.Bang) {
+		return fmt.Errorf("%w: bang=%s", query.ErrInvalidParameter, doc.Bang)
+	}
+
+	nodes, err := query.Decode(doc.Tags)
+	if err != nil {
+		return err
+	}
+	params, err := loadParams(doc.Params)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nodes = nodes
+	e.bang = doc.Bang
+	e.params = params
+	e.err = nil
+
+	return nil
+}
+
+// MarshalJSON encodes the request as a JSON document, including URL parameters and the bang.
+func (e *DuckDuckGo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Document())
+}
+
+// UnmarshalJSON replaces the request with the one of a JSON document.
+func (e *DuckDuckGo) UnmarshalJSON(data []byte) error {
+	var doc query.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	return e.load(doc)
+}
+
+// MarshalText encodes the request as a dork string, prefixed by the !bang if any. URL parameters are not included.
+func (e *DuckDuckGo) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText replaces the tags and bang of the request with the ones of a dork string.
+// URL parameters are kept, along with the error of an invalid one.
+func (e *DuckDuckGo) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nodes = parsed.nodes
+	e.bang = parsed.bang
+	if !errors.Is(e.err, query.ErrInvalidParameter) {
+		e.err = parsed.err
+	}
+
+	return nil
+}
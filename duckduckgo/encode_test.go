@@ -0,0 +1,96 @@
+package duckduckgo_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestEncode(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should encode to a stable JSON document", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Site("example.com").
+			Exclude(duckduckgo.New().InURL("login")).
+			Region("fr-fr").
+			Bang("g")
+
+		data, err := json.Marshal(dork)
+
+		assert.Nil(err)
+		assert.Equal(`{"engine":"duckduckgo","tags":[`+
+			`{"type":"operator","operator":"site","value":"example.com"},`+
+			`{"type":"not","tag":{"type":"operator","operator":"inurl","value":"login","quoted":true}}],`+
+			`"params":{"kl":["fr-fr"]},"bang":"g"}`, string(data), "they should be equal")
+	})
+
+	t.Run("should decode a JSON document identically", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Site("example.com").
+			Or().
+			Group(duckduckgo.New().InText("a").And().InText("b")).
+			DateFilter(duckduckgo.PastWeek).
+			Bang("w")
+		data, _ := json.Marshal(dork)
+
+		result := duckduckgo.New()
+		err := json.Unmarshal(data, result)
+		again, _ := json.Marshal(result)
+
+		assert.Nil(err)
+		assert.Equal(string(data), string(again), "they should be equal")
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+	})
+
+	t.Run("should reject invalid documents", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"engine":"google","tags":[]}`), duckduckgo.New())
+		assert.True(errors.Is(err, query.ErrEngineMismatch), "it should be an engine mismatch error")
+
+		err = json.Unmarshal([]byte(`{"engine":"duckduckgo","tags":[],"bang":"a b"}`), duckduckgo.New())
+		assert.True(errors.Is(err, query.ErrInvalidParameter), "it should be an invalid parameter error")
+	})
+
+	t.Run("should validate URL parameters like the setters", func(t *testing.T) {
+		for _, params := range []string{
+			`{"kp":["bogus"]}`,
+			`{"kl":["xx-xx"]}`,
+			`{"df":["2020-02-01..2020-01-01"]}`,
+			`{"ia":["images"],"iax":["videos"]}`,
+			`{"iax":["images"]}`,
+			`{"unknown":["1"]}`,
+		} {
+			err := json.Unmarshal([]byte(`{"engine":"duckduckgo","tags":[],"params":`+params+`}`), duckduckgo.New())
+
+			assert.True(errors.Is(err, query.ErrInvalidParameter), params)
+		}
+
+		dork = duckduckgo.New().
+			SafeSearch(duckduckgo.SafeSearchOff).
+			DateRange(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)).
+			Vertical(duckduckgo.News)
+
+		result, err := duckduckgo.FromDocument(dork.Document())
+
+		assert.Nil(err)
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+	})
+
+	t.Run("should encode to text", func(t *testing.T) {
+		dork = duckduckgo.New().Site("example.com").Bang("g")
+
+		text, err := dork.MarshalText()
+		assert.Nil(err)
+		assert.Equal(`!g site:example.com`, string(text), "they should be equal")
+
+		result, err := duckduckgo.FromDocument(query.Document{Engine: duckduckgo.EngineName})
+		assert.Nil(err)
+		assert.Nil(result.UnmarshalText(text))
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+	})
+}
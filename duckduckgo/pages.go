@@ -0,0 +1,48 @@
+package duckduckgo
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+const (
+	// resultsPerPage is the number of results returned by DuckDuckGo for each page.
+	resultsPerPage = 30
+	// htmlURL is the HTML frontend of DuckDuckGo. Unlike the JavaScript one, it honors the s offset parameter.
+	htmlURL = "https://html.duckduckgo.com/html/"
+)
+
+// PageURL returns the URL of the given result page, starting at 1.
+// It returns an empty string if the page or the base URL is invalid, use PageURLE to get the error.
+func (e *DuckDuckGo) PageURL(page int) string {
+	u, _ := e.PageURLE(page)
+	return u
+}
+
+// PageURLE returns the URL of the given result page, starting at 1, or returns an error if the page or the base URL is invalid.
+// Pages are computed using the s offset parameter, which is ignored by the JavaScript frontend of duckduckgo.com,
+// so they are requested from the HTML frontend https://html.duckduckgo.com/html/ unless a base URL is set.
+func (e *DuckDuckGo) PageURLE(page int) (string, error) {
+	if page < 1 {
+		return "", fmt.Errorf("%w: page=%d", query.ErrInvalidParameter, page)
+	}
+
+	params := e.QueryValues()
+	if page > 1 {
+		params.Set("s", strconv.Itoa((page-1)*resultsPerPage))
+	}
+
+	return query.BuildURL(e.baseURL, htmlURL, params)
+}
+
+// Pages returns the URLs of the first n result pages.
+func (e *DuckDuckGo) Pages(n int) []string {
+	var pages []string
+	for page := 1; page <= n; page++ {
+		pages = append(pages, e.PageURL(page))
+	}
+
+	return pages
+}
@@ -0,0 +1,47 @@
+package duckduckgo_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestPages(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should return URLs of result pages", func(t *testing.T) {
+		dork = duckduckgo.New().Site("example.com").Region("fr-fr")
+
+		assert.Equal([]string{
+			"https://html.duckduckgo.com/html/?kl=fr-fr&q=site%3Aexample.com",
+			"https://html.duckduckgo.com/html/?kl=fr-fr&q=site%3Aexample.com&s=30",
+			"https://html.duckduckgo.com/html/?kl=fr-fr&q=site%3Aexample.com&s=60",
+		}, dork.Pages(3), "they should be equal")
+	})
+
+	t.Run("should use the base URL", func(t *testing.T) {
+		dork = duckduckgo.New(duckduckgo.WithBaseURL("https://ddg.example.org/html/")).Site("example.com")
+
+		assert.Equal("https://ddg.example.org/html/?q=site%3Aexample.com&s=30", dork.PageURL(2), "they should be equal")
+	})
+
+	t.Run("should reject pages before the first one", func(t *testing.T) {
+		dork = duckduckgo.New().Site("example.com")
+
+		for _, page := range []int{0, -1} {
+			_, err := dork.PageURLE(page)
+
+			assert.True(errors.Is(err, query.ErrInvalidParameter), "it should be an invalid parameter error")
+			assert.Equal("", dork.PageURL(page), "they should be equal")
+		}
+	})
+
+	t.Run("should return no pages", func(t *testing.T) {
+		dork = duckduckgo.New().Site("example.com")
+
+		assert.Empty(dork.Pages(-1))
+	})
+}
@@ -0,0 +1,227 @@
+package duckduckgo
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// SafeSearch is the safe search mode, used as the "kp" URL parameter.
+type SafeSearch string
+
+// Safe search modes
+const (
+	SafeSearchStrict   SafeSearch = "1"
+	SafeSearchModerate SafeSearch = "-1"
+	SafeSearchOff      SafeSearch = "-2"
+)
+
+// DateFilter restricts results to a recent period, used as the "df" URL parameter.
+type DateFilter string
+
+// Date filters
+const (
+	PastDay   DateFilter = "d"
+	PastWeek  DateFilter = "w"
+	PastMonth DateFilter = "m"
+	PastYear  DateFilter = "y"
+)
+
+// Vertical is the kind of results to search for, used as the "ia" URL parameter.
+type Vertical string
+
+// Verticals
+const (
+	Images Vertical = "images"
+	News   Vertical = "news"
+	Videos Vertical = "videos"
+)
+
+const dateFormat = "2006-01-02"
+
+// regions lists the region codes supported by DuckDuckGo.
+var regions = map[string]bool{
+	"wt-wt": true, "xa-ar": true, "xa-en": true, "ar-es": true, "au-en": true, "at-de": true,
+	"be-fr": true, "be-nl": true, "br-pt": true, "bg-bg": true, "ca-en": true, "ca-fr": true,
+	"ct-ca": true, "cl-es": true, "cn-zh": true, "co-es": true, "hr-hr": true, "cz-cs": true,
+	"dk-da": true, "ee-et": true, "fi-fi": true, "fr-fr": true, "de-de": true, "gr-el": true,
+	"hk-tzh": true, "hu-hu": true, "in-en": true, "id-id": true, "id-en": true, "ie-en": true,
+	"il-he": true, "it-it": true, "jp-jp": true, "kr-kr": true, "lv-lv": true, "lt-lt": true,
+	"xl-es": true, "my-ms": true, "my-en": true, "mx-es": true, "nl-nl": true, "nz-en": true,
+	"no-no": true, "pe-es": true, "ph-en": true, "ph-tl": true, "pl-pl": true, "pt-pt": true,
+	"ro-ro": true, "ru-ru": true, "sg-en": true, "sk-sk": true, "sl-sl": true, "za-en": true,
+	"es-es": true, "se-sv": true, "ch-de": true, "ch-fr": true, "ch-it": true, "tw-tzh": true,
+	"th-th": true, "tr-tr": true, "ua-uk": true, "uk-en": true, "us-en": true, "ue-es": true,
+	"ve-es": true, "vn-vi": true,
+}
+
+func (e *DuckDuckGo) set(param string, value string) *DuckDuckGo {
+	e = e.next()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.params == nil {
+		e.params = url.Values{}
+	}
+	e.params.Set(param, value)
+	return e
+}
+
+func (e *DuckDuckGo) invalid(param string, value interface{}) *DuckDuckGo {
+	e = e.next()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err == nil {
+		e.err = fmt.Errorf("%w: %s=%v", query.ErrInvalidParameter, param, value)
+	}
+	return e
+}
+
+// setters maps each URL parameter to the method producing it, so that the parameters of a document
+// are validated the same way as the ones of a request.
+var setters = map[string]func(e *DuckDuckGo, value string) *DuckDuckGo{
+	"kl": (*DuckDuckGo).Region,
+	"kp": func(e *DuckDuckGo, value string) *DuckDuckGo {
+		return e.SafeSearch(SafeSearch(value))
+	},
+	"df": func(e *DuckDuckGo, value string) *DuckDuckGo {
+		i := strings.Index(value, "..")
+		if i < 0 {
+			return e.DateFilter(DateFilter(value))
+		}
+
+		from, fromErr := time.Parse(dateFormat, value[:i])
+		to, toErr := time.Parse(dateFormat, value[i+2:])
+		if fromErr != nil || toErr != nil {
+			return e.invalid("df", value)
+		}
+		return e.DateRange(from, to)
+	},
+	"ia":  setVertical,
+	"iax": setVertical,
+	"iar": setVertical,
+}
+
+func setVertical(e *DuckDuckGo, value string) *DuckDuckGo {
+	return e.Vertical(Vertical(value))
+}
+
+// loadParams checks the URL parameters of a document and returns them as the setters would have set them.
+// Parameters set together, such as ia and iax, must be consistent.
+func loadParams(params url.Values) (url.Values, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	e := &DuckDuckGo{}
+	for _, name := range names {
+		set, ok := setters[name]
+		if !ok || len(params[name]) != 1 {
+			return nil, fmt.Errorf("%w: %s=%s", query.ErrInvalidParameter, name, strings.Join(params[name], ","))
+		}
+		if e = set(e, params[name][0]); e.err != nil {
+			return nil, e.err
+		}
+	}
+
+	for name := range e.params {
+		if params.Get(name) != e.params.Get(name) {
+			return nil, fmt.Errorf("%w: %s=%s", query.ErrInvalidParameter, name, params.Get(name))
+		}
+	}
+
+	return e.params, nil
+}
+
+// Region restricts results to a region, such as "fr-fr" or "us-en" (kl parameter).
+// Use "wt-wt" for no region.
+func (e *DuckDuckGo) Region(region string) *DuckDuckGo {
+	if !regions[region] {
+		return e.invalid("kl", region)
+	}
+	return e.set("kl", region)
+}
+
+// SafeSearch sets the safe search mode (kp parameter).
+func (e *DuckDuckGo) SafeSearch(mode SafeSearch) *DuckDuckGo {
+	switch mode {
+	case SafeSearchStrict, SafeSearchModerate, SafeSearchOff:
+		return e.set("kp", string(mode))
+	}
+	return e.invalid("kp", mode)
+}
+
+// DateFilter restricts results to a recent period (df parameter). It replaces any date range.
+func (e *DuckDuckGo) DateFilter(filter DateFilter) *DuckDuckGo {
+	switch filter {
+	case PastDay, PastWeek, PastMonth, PastYear:
+		return e.set("df", string(filter))
+	}
+	return e.invalid("df", filter)
+}
+
+// DateRange restricts results to a custom period (df parameter). It replaces any date filter.
+func (e *DuckDuckGo) DateRange(from, to time.Time) *DuckDuckGo {
+	value := from.Format(dateFormat) + ".." + to.Format(dateFormat)
+	if to.Before(from) {
+		return e.invalid("df", value)
+	}
+	return e.set("df", value)
+}
+
+// Vertical restricts results to a kind of content, such as images or news (ia parameter).
+func (e *DuckDuckGo) Vertical(v Vertical) *DuckDuckGo {
+	switch v {
+	case Images, Videos:
+		return e.set("ia", string(v)).set("iax", string(v))
+	case News:
+		return e.set("ia", string(v)).set("iar", string(v))
+	}
+	return e.invalid("ia", v)
+}
+
+// Bang redirects the search to another website using its !bang shortcut, such as "g" for Google.
+// See https://duckduckgo.com/bang for the list of bangs.
+func (e *DuckDuckGo) Bang(bang string) *DuckDuckGo {
+	if !isBang(bang) {
+		return e.invalid("bang", bang)
+	}
+
+	e = e.next()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.bang = bang
+	return e
+}
+
+// Bangs returns the !bang of the request without its prefix, if any.
+func (e *DuckDuckGo) Bangs() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.bang == "" {
+		return nil
+	}
+	return []string{e.bang}
+}
+
+func isBang(bang string) bool {
+	if bang == "" {
+		return false
+	}
+	for _, r := range bang {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+
+	return true
+}
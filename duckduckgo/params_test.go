@@ -0,0 +1,92 @@
+package duckduckgo_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestParams(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should set URL parameters", func(t *testing.T) {
+		dork = duckduckgo.New().
+			Site("example.com").
+			Region("fr-fr").
+			SafeSearch(duckduckgo.SafeSearchOff).
+			DateFilter(duckduckgo.PastMonth).
+			Vertical(duckduckgo.Images)
+
+		assert.Nil(dork.Err())
+		assert.Equal(url.Values{
+			"q":   []string{"site:example.com"},
+			"kl":  []string{"fr-fr"},
+			"kp":  []string{"-2"},
+			"df":  []string{"m"},
+			"ia":  []string{"images"},
+			"iax": []string{"images"},
+		}, dork.QueryValues(), "they should be equal")
+		assert.Equal("https://duckduckgo.com/?df=m&ia=images&iax=images&kl=fr-fr&kp=-2&q=site%3Aexample.com", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should set the news vertical", func(t *testing.T) {
+		dork = duckduckgo.New().Vertical(duckduckgo.News)
+
+		assert.Equal("news", dork.QueryValues().Get("ia"), "they should be equal")
+		assert.Equal("news", dork.QueryValues().Get("iar"), "they should be equal")
+	})
+
+	t.Run("should set a custom date range", func(t *testing.T) {
+		dork = duckduckgo.New().
+			DateFilter(duckduckgo.PastDay).
+			DateRange(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal("2020-01-01..2020-02-01", dork.QueryValues().Get("df"), "they should be equal")
+	})
+
+	t.Run("should prefix the request with a bang", func(t *testing.T) {
+		dork = duckduckgo.New().Bang("g").Site("example.com")
+
+		assert.Equal("!g site:example.com", dork.String(), "they should be equal")
+		assert.Equal([]string{"g"}, dork.Bangs(), "they should be equal")
+		assert.Nil(duckduckgo.New().Bangs())
+		assert.Equal("https://duckduckgo.com/?q=%21g+site%3Aexample.com", dork.URL(), "they should be equal")
+
+		result, err := duckduckgo.Parse(dork.String())
+		assert.Nil(err)
+		assert.Equal(dork.String(), result.String(), "they should be equal")
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+
+	t.Run("should keep invalid bangs as terms when parsing", func(t *testing.T) {
+		result, err := duckduckgo.Parse("!bad$bang site:a.com")
+
+		assert.Nil(err)
+		assert.Nil(result.Err())
+		assert.Equal("!bad$bang site:a.com", result.String(), "they should be equal")
+		assert.Equal(query.Term{Text: "!bad$bang"}, result.Query()[0], "they should be equal")
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		cases := map[string]*duckduckgo.DuckDuckGo{
+			"kl=fr":                     duckduckgo.New().Region("fr"),
+			"kp=0":                      duckduckgo.New().SafeSearch("0"),
+			"df=h":                      duckduckgo.New().DateFilter("h"),
+			"df=2020-02-01..2020-01-01": duckduckgo.New().DateRange(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+			"ia=maps":                   duckduckgo.New().Vertical("maps"),
+			"bang=":                     duckduckgo.New().Bang(""),
+			"bang=g site":               duckduckgo.New().Bang("g site"),
+		}
+
+		for expected, dork := range cases {
+			assert.True(errors.Is(dork.Err(), query.ErrInvalidParameter), expected)
+			assert.EqualError(dork.Err(), "invalid parameter: "+expected)
+			assert.Equal(url.Values{"q": []string{""}}, dork.QueryValues(), expected)
+		}
+	})
+}
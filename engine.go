@@ -0,0 +1,53 @@
+package dorkgen
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sundowndev/dorkgen/baidu"
+	"github.com/sundowndev/dorkgen/bingsearch"
+	"github.com/sundowndev/dorkgen/bravesearch"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/githubsearch"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/searxng"
+	"github.com/sundowndev/dorkgen/startpage"
+	"github.com/sundowndev/dorkgen/yahoosearch"
+	"github.com/sundowndev/dorkgen/yandex"
+)
+
+// Renderer is implemented by builders that can be converted to a request.
+type Renderer interface {
+	fmt.Stringer
+	// QueryValues returns search request as URL values
+	QueryValues() url.Values
+	// URL converts tags to an encoded search URL
+	URL() string
+}
+
+// Engine is implemented by every search engine builder.
+// Fluent methods such as Site or InTitle return the concrete builder type,
+// so they can't be part of this interface and must be called on the builder itself.
+type Engine interface {
+	Renderer
+	// Name returns the name of the search engine
+	Name() string
+	// Query returns a copy of the engine-neutral query tree
+	Query() query.Query
+	// Validate checks the request is well-formed
+	Validate() error
+}
+
+var (
+	_ Engine = (*googlesearch.GoogleSearch)(nil)
+	_ Engine = (*duckduckgo.DuckDuckGo)(nil)
+	_ Engine = (*bingsearch.BingSearch)(nil)
+	_ Engine = (*yahoosearch.YahooSearch)(nil)
+	_ Engine = (*yandex.Yandex)(nil)
+	_ Engine = (*baidu.Baidu)(nil)
+	_ Engine = (*bravesearch.BraveSearch)(nil)
+	_ Engine = (*startpage.Startpage)(nil)
+	_ Engine = (*searxng.SearXNG)(nil)
+	_ Engine = (*githubsearch.GitHubSearch)(nil)
+)
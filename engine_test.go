@@ -0,0 +1,51 @@
+package dorkgen
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestEngine(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should iterate over a list of engines", func(t *testing.T) {
+		engines := []Engine{
+			NewGoogleSearch().Site("example.com"),
+			NewDuckDuckGo().Site("example.com"),
+			NewBingSearch().Site("example.com"),
+			NewYahooSearch().Site("example.com"),
+			NewYandex().Site("example.com"),
+			NewBaidu().Site("example.com"),
+			NewBraveSearch().Site("example.com"),
+			NewStartpage().Site("example.com"),
+			NewSearXNG("http://localhost:8888").Site("example.com"),
+		}
+
+		var names, urls []string
+		for _, e := range engines {
+			assert.Equal("site:example.com", e.String(), "they should be equal")
+			names = append(names, e.Name())
+			urls = append(urls, e.URL())
+		}
+
+		assert.Equal([]string{"google", "duckduckgo", "bing", "yahoo", "yandex", "baidu", "brave", "startpage", "searxng"}, names, "they should be equal")
+		assert.Equal([]string{
+			"https://www.google.com/search?q=site%3Aexample.com",
+			"https://duckduckgo.com/?q=site%3Aexample.com",
+			"https://www.bing.com/search?q=site%3Aexample.com",
+			"https://search.yahoo.com/search?p=site%3Aexample.com",
+			"https://yandex.com/search/?text=site%3Aexample.com",
+			"https://www.baidu.com/s?ie=utf-8&wd=site%3Aexample.com",
+			"https://search.brave.com/search?q=site%3Aexample.com",
+			"https://www.startpage.com/sp/search?query=site%3Aexample.com",
+			"http://localhost:8888/search?q=site%3Aexample.com",
+		}, urls, "they should be equal")
+	})
+
+	t.Run("should accept any engine as a renderer", func(t *testing.T) {
+		var r Renderer = NewDuckDuckGo().InTitle("admin")
+
+		assert.Equal("intitle:\"admin\"", r.QueryValues().Get("q"), "they should be equal")
+	})
+}
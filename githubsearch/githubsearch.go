@@ -0,0 +1,353 @@
+package githubsearch
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "github"
+
+const (
+	searchURL    = "https://github.com/search"
+	searchType   = "code"
+	repoTag      = "repo:"
+	orgTag       = "org:"
+	userTag      = "user:"
+	pathTag      = "path:"
+	filenameTag  = "filename:"
+	extensionTag = "extension:"
+	languageTag  = "language:"
+	inTag        = "in:"
+	sizeTag      = "size:"
+	forkTag      = "fork:"
+	excludeTag   = "NOT "
+	operatorOr   = "OR"
+	operatorAnd  = "AND"
+	regexDelim   = "/"
+)
+
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpRepo:     repoTag,
+		query.OpOrg:      orgTag,
+		query.OpUser:     userTag,
+		query.OpPath:     pathTag,
+		query.OpFilename: filenameTag,
+		query.OpExt:      extensionTag,
+		query.OpLanguage: languageTag,
+		query.OpIn:       inTag,
+		query.OpSize:     sizeTag,
+		query.OpFork:     forkTag,
+	},
+	And:      operatorAnd,
+	Or:       operatorOr,
+	Not:      excludeTag,
+	Regex:    regexDelim,
+	Features: query.Regexes,
+}
+
+// Fork defines whether forked repositories are searched, used as the value of the fork qualifier.
+type Fork string
+
+// Fork modes
+const (
+	// WithForks searches forks along with other repositories
+	WithForks Fork = "true"
+	// OnlyForks searches forks only
+	OnlyForks Fork = "only"
+)
+
+// GitHubSearch is the GitHub code search implementation for Dorkgen
+type GitHubSearch struct {
+	nodes   query.Query
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of GitHubSearch
+type Option func(*GitHubSearch)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *GitHubSearch) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as the search page of a GitHub Enterprise server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *GitHubSearch) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of GitHubSearch
+func New(opts ...Option) *GitHubSearch {
+	e := &GitHubSearch{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of GitHubSearch from a query tree
+func FromQuery(q query.Query) *GitHubSearch {
+	return &GitHubSearch{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of GitHubSearch
+func Parse(dork string) (*GitHubSearch, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubSearch{nodes: q}, nil
+}
+
+// Render converts a query tree to GitHub search syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the qualifier is available in GitHub code search
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in GitHub code search
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *GitHubSearch) add(n query.Node) *GitHubSearch {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *GitHubSearch) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+// qualifier adds a qualifier, quoting its value only if it contains spaces, such as language:"Emacs Lisp".
+func (e *GitHubSearch) qualifier(name string, value string) *GitHubSearch {
+	quotes := strings.IndexFunc(value, unicode.IsSpace) >= 0
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *GitHubSearch) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *GitHubSearch) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *GitHubSearch) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+func (e *GitHubSearch) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return query.Validate(e.nodes)
+}
+
+// String converts all tags to a single request
+func (e *GitHubSearch) String() string {
+	return syntax.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values, for the code search of github.com.
+// The REST API endpoint https://api.github.com/search/code runs the legacy code search,
+// which doesn't support parts of this syntax such as regular expressions.
+func (e *GitHubSearch) QueryValues() url.Values {
+	params := url.Values{}
+	params.Set("q", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded GitHub code search URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *GitHubSearch) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded GitHub code search URL, or returns an error if the base URL is invalid.
+func (e *GitHubSearch) URLE() (string, error) {
+	params := e.QueryValues()
+	params.Set("type", searchType)
+
+	return query.BuildURL(e.baseURL, searchURL, params)
+}
+
+// Repo searches code of the given repository, formatted as owner/name such as "sundowndev/dorkgen".
+func (e *GitHubSearch) Repo(repo string) *GitHubSearch {
+	return e.qualifier(query.OpRepo, repo)
+}
+
+// Org searches code of the repositories owned by the given organization.
+func (e *GitHubSearch) Org(org string) *GitHubSearch {
+	return e.qualifier(query.OpOrg, org)
+}
+
+// User searches code of the repositories owned by the given user.
+func (e *GitHubSearch) User(user string) *GitHubSearch {
+	return e.qualifier(query.OpUser, user)
+}
+
+// Path searches files under the given path, such as "config/" or "*.env".
+func (e *GitHubSearch) Path(path string) *GitHubSearch {
+	return e.qualifier(query.OpPath, path)
+}
+
+// Filename searches files with the given name, such as ".npmrc".
+func (e *GitHubSearch) Filename(filename string) *GitHubSearch {
+	return e.qualifier(query.OpFilename, filename)
+}
+
+// Extension searches files with the given extension, such as "pem".
+func (e *GitHubSearch) Extension(ext string) *GitHubSearch {
+	return e.qualifier(query.OpExt, ext)
+}
+
+// Language searches files written in the given language, such as "go" or "Emacs Lisp".
+func (e *GitHubSearch) Language(lang string) *GitHubSearch {
+	return e.qualifier(query.OpLanguage, lang)
+}
+
+// InFile searches keywords in the content of files.
+func (e *GitHubSearch) InFile() *GitHubSearch {
+	return e.qualifier(query.OpIn, "file")
+}
+
+// InPath searches keywords in the path of files.
+func (e *GitHubSearch) InPath() *GitHubSearch {
+	return e.qualifier(query.OpIn, "path")
+}
+
+// Size searches files of the given size in bytes, such as "1000", ">1000", "<=1000" or "1000..2000".
+func (e *GitHubSearch) Size(size string) *GitHubSearch {
+	return e.qualifier(query.OpSize, size)
+}
+
+// Fork includes forked repositories in the search, which are skipped by default.
+func (e *GitHubSearch) Fork(mode Fork) *GitHubSearch {
+	switch mode {
+	case WithForks, OnlyForks:
+	default:
+		e.fail(fmt.Errorf("%w: fork=%s", query.ErrInvalidParameter, mode))
+	}
+
+	return e.qualifier(query.OpFork, string(mode))
+}
+
+// Regex searches code matching the regular expression, such as `AKIA[0-9A-Z]{16}`.
+// Slashes inside the expression are escaped.
+func (e *GitHubSearch) Regex(pattern string) *GitHubSearch {
+	return e.add(query.Regex{Pattern: escapeSlashes(pattern)})
+}
+
+// Phrase searches for an exact string, such as "AWS_SECRET_ACCESS_KEY =".
+func (e *GitHubSearch) Phrase(text string) *GitHubSearch {
+	text, err := query.Sanitize(text, true, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Phrase{Text: text})
+}
+
+// Or puts an OR operator in the request
+func (e *GitHubSearch) Or() *GitHubSearch {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request
+func (e *GitHubSearch) And() *GitHubSearch {
+	return e.add(query.And{})
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them with NOT.
+// GitHub supports excluding a group as a whole.
+func (e *GitHubSearch) Exclude(tags *GitHubSearch) *GitHubSearch {
+	nodes, err := query.Exclude(tags.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *GitHubSearch) Not(tag *GitHubSearch) *GitHubSearch {
+	n, err := query.Negate(tag.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *GitHubSearch) Group(tags *GitHubSearch) *GitHubSearch {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *GitHubSearch) Plain(value string) *GitHubSearch {
+	return e.add(query.Term{Text: value})
+}
+
+// escapeSlashes escapes the slashes of a regular expression that are not escaped yet.
+func escapeSlashes(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '\\' && i+1 < len(pattern) {
+			b.WriteString(pattern[i : i+2])
+			i++
+			continue
+		}
+		if pattern[i] == '/' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(pattern[i])
+	}
+
+	return b.String()
+}
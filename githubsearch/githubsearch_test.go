@@ -0,0 +1,172 @@
+package githubsearch_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/githubsearch"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+var dork *githubsearch.GitHubSearch
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = githubsearch.New()
+
+		result := dork.
+			Org("example").
+			Filename(".env").
+			URL()
+
+		assert.Equal("https://github.com/search?q=org%3Aexample+filename%3A.env&type=code", result, "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = githubsearch.New()
+
+		result := fmt.Sprint(dork.Repo("sundowndev/dorkgen"))
+
+		assert.Equal("repo:sundowndev/dorkgen", result, "they should be equal")
+	})
+
+	t.Run("should handle qualifiers correctly", func(t *testing.T) {
+		dork = githubsearch.New()
+
+		result := dork.
+			Repo("sundowndev/dorkgen").
+			User("sundowndev").
+			Path("config/").
+			Extension("pem").
+			Language("Emacs Lisp").
+			InFile().
+			InPath().
+			Size(">1000").
+			Fork(githubsearch.OnlyForks).
+			String()
+
+		assert.Equal("repo:sundowndev/dorkgen user:sundowndev path:config/ extension:pem language:\"Emacs Lisp\" in:file in:path size:>1000 fork:only", result, "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should handle regular expressions and phrases correctly", func(t *testing.T) {
+		dork = githubsearch.New()
+
+		result := dork.
+			Regex(`AKIA[0-9A-Z]{16}`).
+			Or().
+			Regex(`https?://[^\/]+`).
+			Phrase("AWS_SECRET_ACCESS_KEY =").
+			String()
+
+		assert.Equal(`/AKIA[0-9A-Z]{16}/ OR /https?:\/\/[^\/]+/ "AWS_SECRET_ACCESS_KEY ="`, result, "they should be equal")
+		assert.True(errors.Is(githubsearch.New().Regex("").Err(), query.ErrInvalidTerm), "it should be an invalid term error")
+	})
+
+	t.Run("should handle quotes and backslashes in regular expressions", func(t *testing.T) {
+		dork = githubsearch.New().Regex(`key="`).Regex(`C:\\`)
+
+		assert.Nil(dork.Err())
+		assert.Equal(`/key="/ /C:\\/`, dork.String(), "they should be equal")
+
+		result, err := githubsearch.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+
+		dork = githubsearch.New().Regex(`abc\`)
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidTerm), "it should be an invalid term error")
+	})
+
+	t.Run("should handle exclusions and groups correctly", func(t *testing.T) {
+		dork = githubsearch.New()
+
+		result := dork.
+			Group(githubsearch.New().Language("go").Or().Language("python")).
+			And().
+			Filename("credentials").
+			Exclude(githubsearch.New().Path("test/").Group(githubsearch.New().Org("a").Or().Org("b"))).
+			String()
+
+		assert.Equal("(language:go OR language:python) AND filename:credentials NOT path:test/ NOT (org:a OR org:b)", result, "they should be equal")
+	})
+
+	t.Run("should be compatible with the REST API", func(t *testing.T) {
+		dork = githubsearch.New().Filename(".npmrc").Phrase("_auth")
+
+		assert.Equal(url.Values{
+			"q": []string{"filename:.npmrc \"_auth\""},
+		}, dork.QueryValues(), "they should be equal")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = githubsearch.New(githubsearch.WithBaseURL("https://github.example.com/search?type=repositories")).Org("example")
+
+		assert.Equal("https://github.example.com/search?q=org%3Aexample&type=code", dork.URL(), "they should be equal")
+
+		_, err := githubsearch.New(githubsearch.WithBaseURL("search")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+
+	t.Run("should validate the request", func(t *testing.T) {
+		assert.True(errors.Is(githubsearch.New().Repo("dorkgen").Err(), query.ErrInvalidRepository), "it should be an invalid repository error")
+		assert.True(errors.Is(githubsearch.New().Size("1kb").Err(), query.ErrInvalidRange), "it should be an invalid range error")
+		assert.True(errors.Is(githubsearch.New().Extension(".pem").Validate(), query.ErrInvalidExtension), "it should be an invalid extension error")
+		assert.EqualError(githubsearch.New().Fork("false").Err(), "tag 0: invalid parameter: fork=false")
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should round trip", func(t *testing.T) {
+		dork = githubsearch.New().
+			Org("example").
+			Language("Emacs Lisp").
+			Regex(`api_key=[a-z0-9]+`).
+			Size("100..2000").
+			Not(githubsearch.New().Path("vendor/"))
+
+		result, err := githubsearch.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+		assert.Equal(dork.String(), result.String(), "they should be equal")
+	})
+
+	t.Run("should parse regular expressions as a single term", func(t *testing.T) {
+		dork = githubsearch.New().
+			Regex(`(AKIA|ASIA) [0-9A-Z]{16}`).
+			Not(githubsearch.New().Regex(`a/b "c"`)).
+			Path("config/")
+
+		result, err := githubsearch.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(`/(AKIA|ASIA) [0-9A-Z]{16}/ NOT /a\/b "c"/ path:config/`, result.String(), "they should be equal")
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should render a query built for another engine", func(t *testing.T) {
+		q := googlesearch.New().
+			Ext("pem").
+			Plain("BEGIN").
+			Query()
+
+		assert.Equal("extension:pem BEGIN", githubsearch.Render(q), "they should be equal")
+		assert.Equal("extension:pem BEGIN", githubsearch.FromQuery(q).String(), "they should be equal")
+		assert.True(githubsearch.Supports(query.OpFilename))
+		assert.False(githubsearch.Supports(query.OpSite))
+	})
+}
@@ -0,0 +1,103 @@
+package googlesearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// Document returns the request as a document, which can be saved as JSON or YAML.
+func (e *GoogleSearch) Document() query.Document {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	doc := query.Document{Engine: EngineName, Tags: query.Encode(e.nodes)}
+	if len(e.params) > 0 {
+		doc.Params = url.Values{}
+		for k, v := range e.params {
+			doc.Params[k] = append([]string(nil), v...)
+		}
+	}
+
+	return doc
+}
+
+// FromDocument creates a new instance of GoogleSearch from a document saved as JSON or YAML.
+func FromDocument(doc query.Document, opts ...Option) (*GoogleSearch, error) {
+	e := New(opts...)
+	if err := e.load(doc); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// load replaces the tags and URL parameters of the instance with the ones of the document.
+func (e *GoogleSearch) load(doc query.Document) error {
+	if doc.Engine != EngineName {
+		return fmt.Errorf("%w: %q", query.ErrEngineMismatch, doc.Engine)
+	}
+	if doc.Bang != "" {
+		return fmt.Errorf("%w: bang=%s", query.ErrInvalidParameter, doc.Bang)
+	}
+
+	nodes, err := query.Decode(doc.Tags)
+	if err != nil {
+		return err
+	}
+	params, err := loadParams(doc.Params)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nodes = nodes
+	e.params = params
+	e.err = nil
+
+	return nil
+}
+
+// MarshalJSON encodes the request as a JSON document, including URL parameters.
+func (e *GoogleSearch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Document())
+}
+
+// UnmarshalJSON replaces the tags and URL parameters of the request with the ones of a JSON document.
+func (e *GoogleSearch) UnmarshalJSON(data []byte) error {
+	var doc query.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	return e.load(doc)
+}
+
+// MarshalText encodes the request as a dork string. URL parameters are not included.
+func (e *GoogleSearch) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText replaces the tags of the request with the ones of a dork string.
+// URL parameters are kept, along with the error of an invalid one.
+func (e *GoogleSearch) UnmarshalText(text []byte) error {
+	nodes, err := syntax.Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.nodes = nodes
+	if !errors.Is(e.err, query.ErrInvalidParameter) {
+		e.err = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package googlesearch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestEncode(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should encode to a stable JSON document", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			Or().
+			Group(googlesearch.New().InText("admin")).
+			Exclude(googlesearch.New().Ext("pdf")).
+			SafeSearch(googlesearch.SafeSearchActive)
+
+		data, err := json.Marshal(dork)
+
+		assert.Nil(err)
+		assert.Equal(`{"engine":"google","tags":[`+
+			`{"type":"operator","operator":"site","value":"example.com"},`+
+			`{"type":"or"},`+
+			`{"type":"group","tags":[{"type":"operator","operator":"intext","value":"admin","quoted":true}]},`+
+			`{"type":"not","tag":{"type":"operator","operator":"ext","value":"pdf"}}],`+
+			`"params":{"safe":["active"]}}`, string(data), "they should be equal")
+	})
+
+	t.Run("should decode a JSON document identically", func(t *testing.T) {
+		dork = googlesearch.New().
+			Around("admin", 3, "password").
+			Wildcard().
+			Range("1", "10").
+			Num(50)
+		data, _ := json.Marshal(dork)
+
+		result := googlesearch.New()
+		err := json.Unmarshal(data, result)
+		again, _ := json.Marshal(result)
+
+		assert.Nil(err)
+		assert.Equal(string(data), string(again), "they should be equal")
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+	})
+
+	t.Run("should keep options when decoding", func(t *testing.T) {
+		doc := googlesearch.New().Site("example.com").Document()
+
+		result, err := googlesearch.FromDocument(doc, googlesearch.WithGoogleDomain("fr"))
+
+		assert.Nil(err)
+		assert.Equal("https://www.google.fr/search?q=site%3Aexample.com", result.URL(), "they should be equal")
+	})
+
+	t.Run("should reject documents of other engines", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"engine":"duckduckgo","tags":[]}`), googlesearch.New())
+
+		assert.True(errors.Is(err, query.ErrEngineMismatch), "it should be an engine mismatch error")
+	})
+
+	t.Run("should reject invalid documents", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"engine":"google","tags":[{"type":"unknown"}]}`), googlesearch.New())
+		assert.True(errors.Is(err, query.ErrInvalidTag), "it should be an invalid tag error")
+
+		err = json.Unmarshal([]byte(`{"engine":"google","tags":[],"bang":"g"}`), googlesearch.New())
+		assert.True(errors.Is(err, query.ErrInvalidParameter), "it should be an invalid parameter error")
+	})
+
+	t.Run("should validate URL parameters like the setters", func(t *testing.T) {
+		for _, params := range []string{
+			`{"num":["5000"]}`,
+			`{"num":["ten"]}`,
+			`{"hl":["fr"],"unknown":["1"]}`,
+			`{"safe":["active","off"]}`,
+			`{"tbs":["qdr:x"]}`,
+			`{"tbs":["cdr:1,cd_min:2/1/2020,cd_max:1/1/2020"]}`,
+			`{"cr":["FR"]}`,
+		} {
+			err := json.Unmarshal([]byte(`{"engine":"google","tags":[],"params":`+params+`}`), googlesearch.New())
+
+			assert.True(errors.Is(err, query.ErrInvalidParameter), params)
+		}
+
+		dork = googlesearch.New().
+			HostLanguage("fr").
+			GeoLocation("fr").
+			LanguageRestrict("fr").
+			CountryRestrict("fr").
+			Start(10).
+			Filter(false).
+			DateRange(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)).
+			ResultType(googlesearch.News)
+
+		result, err := googlesearch.FromDocument(dork.Document())
+
+		assert.Nil(err)
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+	})
+
+	t.Run("should encode to text", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com").InText("admin").Num(20)
+
+		text, err := dork.MarshalText()
+		assert.Nil(err)
+		assert.Equal(`site:example.com intext:"admin"`, string(text), "they should be equal")
+
+		result := googlesearch.New().Num(20)
+		assert.Nil(result.UnmarshalText(text))
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+
+		assert.NotNil(result.UnmarshalText([]byte(`intext:"admin`)))
+
+		result = googlesearch.New().Num(5000)
+		assert.Nil(result.UnmarshalText(text))
+		assert.True(errors.Is(result.Err(), query.ErrInvalidParameter), "it should be an invalid parameter error")
+	})
+}
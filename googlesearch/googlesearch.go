@@ -1,177 +1,612 @@
 package googlesearch
 
 import (
+	"fmt"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/sundowndev/dorkgen/query"
 )
 
+// EngineName is the name identifying this search engine.
+const EngineName = "google"
+
 const (
-	searchURL    = "https://www.google.com/search"
-	siteTag      = "site:"
-	urlTag       = "inurl:"
-	filetypeTag  = "filetype:"
-	cacheTag     = "cache:"
-	relatedTag   = "related:"
-	extTag       = "ext:"
-	excludeTag   = "-"
-	intitleTag   = "intitle:"
-	intextTag    = "intext:"
-	operatorOr   = "|"
-	operatorAnd  = "+"
-	bookTag      = "book:"
-	ipTag        = "ip:"
-	mapsTag      = "maps:"
-	allintextTag = "allintext:"
-	infoTag      = "info:"
-	inanchorTag  = "inanchor:"
+	searchURL       = "https://www.google.com/search"
+	siteTag         = "site:"
+	urlTag          = "inurl:"
+	filetypeTag     = "filetype:"
+	cacheTag        = "cache:"
+	relatedTag      = "related:"
+	extTag          = "ext:"
+	excludeTag      = "-"
+	intitleTag      = "intitle:"
+	intextTag       = "intext:"
+	operatorOr      = "|"
+	operatorAnd     = "+"
+	operatorAround  = "AROUND"
+	bookTag         = "book:"
+	ipTag           = "ip:"
+	mapsTag         = "maps:"
+	allintextTag    = "allintext:"
+	infoTag         = "info:"
+	inanchorTag     = "inanchor:"
+	beforeTag       = "before:"
+	afterTag        = "after:"
+	allintitleTag   = "allintitle:"
+	allinurlTag     = "allinurl:"
+	allinanchorTag  = "allinanchor:"
+	inpostauthorTag = "inpostauthor:"
+	sourceTag       = "source:"
+	defineTag       = "define:"
+	stocksTag       = "stocks:"
+	weatherTag      = "weather:"
+	locationTag     = "location:"
 )
 
-// GoogleSearch is the Google search implementation for Dorkgen
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:         siteTag,
+		query.OpInURL:        urlTag,
+		query.OpFileType:     filetypeTag,
+		query.OpCache:        cacheTag,
+		query.OpRelated:      relatedTag,
+		query.OpExt:          extTag,
+		query.OpInTitle:      intitleTag,
+		query.OpInText:       intextTag,
+		query.OpBook:         bookTag,
+		query.OpIP:           ipTag,
+		query.OpMaps:         mapsTag,
+		query.OpAllInText:    allintextTag,
+		query.OpInfo:         infoTag,
+		query.OpInAnchor:     inanchorTag,
+		query.OpBefore:       beforeTag,
+		query.OpAfter:        afterTag,
+		query.OpAllInTitle:   allintitleTag,
+		query.OpAllInURL:     allinurlTag,
+		query.OpAllInAnchor:  allinanchorTag,
+		query.OpInPostAuthor: inpostauthorTag,
+		query.OpSource:       sourceTag,
+		query.OpDefine:       defineTag,
+		query.OpStocks:       stocksTag,
+		query.OpWeather:      weatherTag,
+		query.OpLocation:     locationTag,
+	},
+	And:      operatorAnd,
+	Or:       operatorOr,
+	Not:      excludeTag,
+	Around:   operatorAround,
+	Features: query.Proximity | query.Wildcards | query.Ranges,
+}
+
+// standaloneOperators lists the operators Google applies to the whole request,
+// which can't be combined with other operators.
+var standaloneOperators = []string{
+	query.OpAllInText,
+	query.OpAllInTitle,
+	query.OpAllInURL,
+	query.OpAllInAnchor,
+}
+
+// GoogleSearch is the Google search implementation for Dorkgen.
+// It is safe for concurrent use, although tags added concurrently are appended in no particular order.
 type GoogleSearch struct {
-	tags []string
+	// mu guards nodes, params and err, other fields are only set by options
+	mu      sync.RWMutex
+	nodes   query.Query
+	params  url.Values
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+	// baseURLErr is set by options given an invalid base URL, and reported by URLE
+	baseURLErr error
+	// immutable makes every method return a modified copy instead of modifying the receiver
+	immutable bool
+	strict    bool
+}
+
+// Option configures an instance of GoogleSearch
+type Option func(*GoogleSearch)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *GoogleSearch) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *GoogleSearch) {
+		e.baseURL = baseURL
+		e.baseURLErr = nil
+	}
+}
+
+// WithGoogleDomain sets the localized Google domain requests are sent to, such as "fr" or "co.uk".
+// An invalid domain is reported by URLE.
+func WithGoogleDomain(domain string) Option {
+	return func(e *GoogleSearch) {
+		e.baseURL = "https://www.google." + domain + "/search"
+		e.baseURLErr = nil
+		if !isDomainSuffix(domain) {
+			e.baseURLErr = fmt.Errorf("%w: Google domain %q", query.ErrInvalidBaseURL, domain)
+		}
+	}
+}
+
+// isDomainSuffix reports whether the domain is made of valid labels, such as "co.uk".
+func isDomainSuffix(domain string) bool {
+	if domain == "" {
+		return false
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// WithImmutable makes every method return a modified copy of the instance instead of modifying it,
+// so a base dork can be shared and extended in several ways.
+func WithImmutable() Option {
+	return func(e *GoogleSearch) {
+		e.immutable = true
+	}
 }
 
 // New creates a new instance of GoogleSearch
-func New() *GoogleSearch {
-	return &GoogleSearch{}
+func New(opts ...Option) *GoogleSearch {
+	e := &GoogleSearch{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of GoogleSearch from a query tree
+func FromQuery(q query.Query) *GoogleSearch {
+	return &GoogleSearch{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of GoogleSearch
+func Parse(dork string) (*GoogleSearch, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoogleSearch{nodes: q}, nil
+}
+
+// Render converts a query tree to Google Search syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in Google Search
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Google Search
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+// Syntax returns a copy of the Google Search syntax, for engines that relay requests to Google
+// or understand Google-style operators. It can be modified without affecting Google Search.
+func Syntax() *query.Syntax {
+	s := *syntax
+	s.Operators = make(map[string]string, len(syntax.Operators))
+	for name, prefix := range syntax.Operators {
+		s.Operators[name] = prefix
+	}
+
+	return &s
+}
+
+// Clone returns a deep copy of the instance, which can be modified without affecting the original.
+func (e *GoogleSearch) Clone() *GoogleSearch {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	c := &GoogleSearch{
+		nodes:      e.nodes.Copy(),
+		err:        e.err,
+		escape:     e.escape,
+		baseURL:    e.baseURL,
+		baseURLErr: e.baseURLErr,
+		immutable:  e.immutable,
+		strict:     e.strict,
+	}
+	if e.params != nil {
+		c.params = make(url.Values, len(e.params))
+		for k, v := range e.params {
+			c.params[k] = append([]string(nil), v...)
+		}
+	}
+
+	return c
+}
+
+// next returns the instance to modify: a copy in immutable mode, the instance itself otherwise.
+func (e *GoogleSearch) next() *GoogleSearch {
+	if e.immutable {
+		return e.Clone()
+	}
+
+	return e
+}
+
+func (e *GoogleSearch) add(n query.Node) *GoogleSearch {
+	return e.next().push(nil, n)
+}
+
+// push records the error, if any, and appends nodes to the instance itself, even in immutable mode.
+func (e *GoogleSearch) push(err error, nodes ...query.Node) *GoogleSearch {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.fail(err)
+	}
+	for _, n := range nodes {
+		if err := query.ValidateNext(e.nodes, n); err != nil {
+			e.fail(err)
+		}
+		if w, ok := firstDeprecated(query.Query{n}); e.strict && ok {
+			e.fail(errDeprecated(w))
+		}
+		e.nodes = append(e.nodes, n)
+	}
+
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+// The caller must hold the lock.
+func (e *GoogleSearch) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *GoogleSearch) operator(name string, value string, quotes bool) *GoogleSearch {
+	value, err := query.Sanitize(value, quotes, e.escape)
+
+	return e.next().push(err, query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *GoogleSearch) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *GoogleSearch) Query() query.Query {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *GoogleSearch) Err() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.err
 }
 
-func (e *GoogleSearch) join(tag string, value string, quotes bool) string {
-	if quotes {
-		return tag + "\"" + value + "\""
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+// Operators such as allintitle can't be combined with other operators.
+// In strict mode, operators that Google no longer honors are rejected too.
+func (e *GoogleSearch) Validate() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := query.Validate(e.nodes); err != nil {
+		return err
+	}
+
+	if w, ok := firstDeprecated(e.nodes); e.strict && ok {
+		return &query.ValidationError{Index: w.Index, Err: errDeprecated(w)}
 	}
 
-	return tag + value
+	return query.ValidateStandalone(e.nodes, standaloneOperators...)
 }
 
 // String converts all tags to a single request
 func (e *GoogleSearch) String() string {
-	return strings.Join(e.tags, " ")
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return syntax.Render(e.nodes)
 }
 
-// QueryValues returns search request as URL values
+// QueryValues returns search request as URL values, including URL parameters
 func (e *GoogleSearch) QueryValues() url.Values {
-	tags := strings.Join(e.tags, " ")
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 
 	params := url.Values{}
-	params.Add("q", tags)
+	for k, v := range e.params {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("q", syntax.Render(e.nodes))
 
 	return params
 }
 
-// URL converts tags to an encoded Google Search URL
+// URL converts tags to an encoded Google Search URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
 func (e *GoogleSearch) URL() string {
-	baseURL, _ := url.Parse(searchURL)
+	u, _ := e.encode(e.QueryValues())
+	return u
+}
+
+// URLE converts tags to an encoded Google Search URL, or returns an error if the base URL is invalid.
+func (e *GoogleSearch) URLE() (string, error) {
+	return e.encode(e.QueryValues())
+}
 
-	baseURL.RawQuery = e.QueryValues().Encode()
+func (e *GoogleSearch) encode(params url.Values) (string, error) {
+	if e.baseURLErr != nil {
+		return "", e.baseURLErr
+	}
 
-	return baseURL.String()
+	return query.BuildURL(e.baseURL, searchURL, params)
 }
 
 // Site specifically searches that particular site and lists all the results for that site.
 func (e *GoogleSearch) Site(site string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(siteTag, site, false))
-	return e
+	return e.operator(query.OpSite, site, false)
 }
 
 // Or puts an OR operator in the request
 func (e *GoogleSearch) Or() *GoogleSearch {
-	e.tags = append(e.tags, operatorOr)
-	return e
+	return e.add(query.Or{})
 }
 
 // And puts an AND operator in the request
+//
+// Deprecated: Google no longer honors + as an AND operator, keywords are required by default.
 func (e *GoogleSearch) And() *GoogleSearch {
-	e.tags = append(e.tags, operatorAnd)
-	return e
+	return e.add(query.And{})
 }
 
 // InText searches for the occurrences of keywords all at once or one at a time.
 func (e *GoogleSearch) InText(text string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(intextTag, text, true))
-	return e
+	return e.operator(query.OpInText, text, true)
 }
 
 // InURL searches for a URL matching one of the keywords.
 func (e *GoogleSearch) InURL(url string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(urlTag, url, true))
-	return e
+	return e.operator(query.OpInURL, url, true)
 }
 
 // FileType searches for a particular filetype mentioned in the query.
 func (e *GoogleSearch) FileType(filetype string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(filetypeTag, filetype, true))
-	return e
+	return e.operator(query.OpFileType, filetype, true)
 }
 
 // Cache shows the version of the web page that Google has in its cache.
+//
+// Deprecated: Google no longer links to cached pages.
 func (e *GoogleSearch) Cache(url string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(cacheTag, url, true))
-	return e
+	return e.operator(query.OpCache, url, true)
 }
 
 // Related list web pages that are “similar” to a specified web page.
+//
+// Deprecated: Google no longer honors the related operator.
 func (e *GoogleSearch) Related(url string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(relatedTag, url, true))
-	return e
+	return e.operator(query.OpRelated, url, true)
 }
 
 // Ext searches for a particular file extension mentioned in the query.
 func (e *GoogleSearch) Ext(ext string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(extTag, ext, false))
-	return e
+	return e.operator(query.OpExt, ext, false)
 }
 
-// Exclude excludes some results.
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Google Search supports excluding a group as a whole.
 func (e *GoogleSearch) Exclude(tags *GoogleSearch) *GoogleSearch {
-	e.tags = append(e.tags, e.join(excludeTag, tags.String(), false))
-	return e
+	nodes, err := query.Exclude(tags.Query(), true)
+	if err != nil {
+		return e.next().push(err)
+	}
+
+	return e.next().push(nil, nodes...)
+}
+
+// Not excludes results matching a single tag.
+func (e *GoogleSearch) Not(tag *GoogleSearch) *GoogleSearch {
+	n, err := query.Negate(tag.Query(), true)
+	if err != nil {
+		return e.next().push(err)
+	}
+
+	return e.next().push(nil, n)
 }
 
 // Group isolate tags between parentheses
 func (e *GoogleSearch) Group(tags *GoogleSearch) *GoogleSearch {
-	e.tags = append(e.tags, "("+tags.String()+")")
-	return e
+	return e.add(query.Group{Nodes: tags.Query()})
 }
 
 // InTitle searches for occurrences of keywords in title all or one.
 func (e *GoogleSearch) InTitle(value string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(intitleTag, value, true))
-	return e
+	return e.operator(query.OpInTitle, value, true)
 }
 
 // Plain allows you to add additional values as string without any kind of formatting.
 func (e *GoogleSearch) Plain(value string) *GoogleSearch {
-	e.tags = append(e.tags, value)
-	return e
+	return e.add(query.Term{Text: value})
 }
 
 // Book searches for book titles related to keywords.
+//
+// Deprecated: Google no longer honors the book operator, use ResultType(Books) instead.
 func (e *GoogleSearch) Book(keyword string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(bookTag, keyword, true))
-	return e
+	return e.operator(query.OpBook, keyword, true)
 }
 
 // Maps searches for maps related to keywords.
+//
+// Deprecated: Google no longer honors the maps operator.
 func (e *GoogleSearch) Maps(location string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(mapsTag, location, false))
-	return e
+	return e.operator(query.OpMaps, location, false)
 }
 
 // AllInText searches text of page.
+// It can't be combined with other operators.
 func (e *GoogleSearch) AllInText(text string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(allintextTag, text, true))
-	return e
+	return e.operator(query.OpAllInText, text, true)
+}
+
+// AllInTitle searches for pages with all the keywords in their title. Unlike InTitle, each word doesn't need its own operator.
+// Still honored by Google. It can't be combined with other operators.
+func (e *GoogleSearch) AllInTitle(text string) *GoogleSearch {
+	return e.operator(query.OpAllInTitle, text, true)
+}
+
+// AllInURL searches for pages with all the keywords in their URL.
+// Still honored by Google. It can't be combined with other operators.
+func (e *GoogleSearch) AllInURL(text string) *GoogleSearch {
+	return e.operator(query.OpAllInURL, text, true)
+}
+
+// AllInAnchor searches for pages linked with all the keywords in the anchor text.
+// Unreliable, Google only honors it partially. It can't be combined with other operators.
+func (e *GoogleSearch) AllInAnchor(text string) *GoogleSearch {
+	return e.operator(query.OpAllInAnchor, text, true)
+}
+
+// InPostAuthor searches for blog posts written by the given author.
+//
+// Deprecated: Google no longer honors the inpostauthor operator since Google Blog Search was retired.
+func (e *GoogleSearch) InPostAuthor(author string) *GoogleSearch {
+	return e.operator(query.OpInPostAuthor, author, true)
+}
+
+// Source searches Google News for articles from the given news source, such as "theguardian".
+// Still honored by Google News only.
+func (e *GoogleSearch) Source(source string) *GoogleSearch {
+	return e.operator(query.OpSource, source, false)
+}
+
+// Define shows the definition of a word or phrase.
+// Still honored by Google.
+func (e *GoogleSearch) Define(word string) *GoogleSearch {
+	return e.operator(query.OpDefine, word, true)
+}
+
+// Stocks shows stock information for the given ticker symbol, such as "goog".
+//
+// Deprecated: Google no longer honors the stocks operator and treats the value as a regular keyword.
+func (e *GoogleSearch) Stocks(ticker string) *GoogleSearch {
+	return e.operator(query.OpStocks, ticker, false)
+}
+
+// Weather shows the weather for the given location.
+// Partially honored, Google may treat the value as a regular keyword.
+func (e *GoogleSearch) Weather(location string) *GoogleSearch {
+	return e.operator(query.OpWeather, location, true)
+}
+
+// Location searches Google News for articles about the given location.
+//
+// Deprecated: Google News no longer honors the location operator, use CountryRestrict instead.
+func (e *GoogleSearch) Location(location string) *GoogleSearch {
+	return e.operator(query.OpLocation, location, true)
 }
 
 // Info presents some information that Google has about a web page, including similar pages, the cached version of the page, and sites linking to the page.
+//
+// Deprecated: Google no longer honors the info operator, use Site instead.
 func (e *GoogleSearch) Info(url string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(infoTag, url, true))
-	return e
+	return e.operator(query.OpInfo, url, true)
 }
 
 // InAnchor search link anchor text.
 func (e *GoogleSearch) InAnchor(text string) *GoogleSearch {
-	e.tags = append(e.tags, e.join(inanchorTag, text, true))
-	return e
+	return e.operator(query.OpInAnchor, text, true)
+}
+
+// Before searches for results published before the given date.
+func (e *GoogleSearch) Before(date time.Time) *GoogleSearch {
+	return e.operator(query.OpBefore, date.Format(query.DateFormat), false)
+}
+
+// After searches for results published after the given date.
+func (e *GoogleSearch) After(date time.Time) *GoogleSearch {
+	return e.operator(query.OpAfter, date.Format(query.DateFormat), false)
+}
+
+// Between searches for results published between the given dates, using both after and before operators.
+func (e *GoogleSearch) Between(from, to time.Time) *GoogleSearch {
+	if to.Before(from) {
+		return e.next().push(query.ErrInvalidDateRange)
+	}
+
+	return e.After(from).Before(to)
+}
+
+// Term searches for a single keyword, without any operator.
+func (e *GoogleSearch) Term(word string) *GoogleSearch {
+	var err error
+	if word == "" || strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+		err = fmt.Errorf("%w: %q", query.ErrInvalidTerm, word)
+	}
+
+	return e.next().push(err, query.Term{Text: word})
+}
+
+// Phrase searches for an exact phrase, without any operator. Use "*" inside the phrase as a wildcard.
+func (e *GoogleSearch) Phrase(text string) *GoogleSearch {
+	text, err := query.Sanitize(text, true, e.escape)
+
+	return e.next().push(err, query.Phrase{Text: text})
+}
+
+// Around searches for two phrases separated by at most n words, such as "admin" AROUND(3) "password".
+func (e *GoogleSearch) Around(a string, n int, b string) *GoogleSearch {
+	left, err := query.Sanitize(a, true, e.escape)
+	right, rightErr := query.Sanitize(b, true, e.escape)
+	if err == nil {
+		err = rightErr
+	}
+
+	return e.next().push(err, query.Around{Left: query.Phrase{Text: left}, Right: query.Phrase{Text: right}, Distance: n})
+}
+
+// Wildcard matches any word, such as in "admin * password".
+func (e *GoogleSearch) Wildcard() *GoogleSearch {
+	return e.add(query.Wildcard{})
+}
+
+// Range searches for numbers between two bounds, such as Range("$100", "$500").
+func (e *GoogleSearch) Range(low, high string) *GoogleSearch {
+	return e.add(query.Range{Low: low, High: high})
 }
@@ -1,10 +1,18 @@
 package googlesearch_test
 
 import (
+	"errors"
 	"fmt"
+	"github.com/sundowndev/dorkgen/duckduckgo"
 	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	assertion "github.com/stretchr/testify/assert"
 )
@@ -206,6 +214,27 @@ func TestInit(t *testing.T) {
 		assert.Equal("allintext:\"test\"", result, "they should be equal")
 	})
 
+	t.Run("should use allin tags", func(t *testing.T) {
+		assert.Equal("allintitle:\"admin login\"", googlesearch.New().AllInTitle("admin login").String(), "they should be equal")
+		assert.Equal("allinurl:\"admin login\"", googlesearch.New().AllInURL("admin login").String(), "they should be equal")
+		assert.Equal("allinanchor:\"admin login\"", googlesearch.New().AllInAnchor("admin login").String(), "they should be equal")
+	})
+
+	t.Run("should use news and answer tags", func(t *testing.T) {
+		dork = googlesearch.New()
+
+		result := dork.
+			InPostAuthor("john doe").
+			Source("theguardian").
+			Define("dork").
+			Stocks("goog").
+			Weather("new york").
+			Location("paris").
+			String()
+
+		assert.Equal("inpostauthor:\"john doe\" source:theguardian define:\"dork\" stocks:goog weather:\"new york\" location:\"paris\"", result, "they should be equal")
+	})
+
 	t.Run("should use info tag", func(t *testing.T) {
 		dork = googlesearch.New()
 
@@ -226,3 +255,558 @@ func TestInit(t *testing.T) {
 		assert.Equal("inanchor:\"test\"", result, "they should be equal")
 	})
 }
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should expose the query tree", func(t *testing.T) {
+		dork = googlesearch.New()
+
+		result := dork.
+			Site("example.com").
+			Exclude(googlesearch.New().InText("admin")).
+			Query()
+
+		assert.Equal(query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.com"},
+			query.Not{Node: query.Operator{Name: query.OpInText, Value: "admin", Quoted: true}},
+		}, result, "they should be equal")
+	})
+
+	t.Run("should render a query built for another engine", func(t *testing.T) {
+		q := duckduckgo.New().
+			Site("example.com").
+			Or().
+			InTitle("admin").
+			Query()
+
+		assert.Equal("site:example.com | intitle:\"admin\"", googlesearch.Render(q), "they should be equal")
+		assert.Equal("site:example.com | intitle:\"admin\"", googlesearch.FromQuery(q).String(), "they should be equal")
+	})
+
+	t.Run("should return a copy of the syntax", func(t *testing.T) {
+		syntax := googlesearch.Syntax()
+		delete(syntax.Operators, query.OpSite)
+		syntax.Or = "OR"
+
+		assert.True(googlesearch.Supports(query.OpSite))
+		assert.Equal("site:a.com | site:b.com", googlesearch.FromQuery(query.Query{
+			query.Operator{Name: query.OpSite, Value: "a.com"},
+			query.Or{},
+			query.Operator{Name: query.OpSite, Value: "b.com"},
+		}).String(), "they should be equal")
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should parse a dork string", func(t *testing.T) {
+		result, err := googlesearch.Parse(`site:example.com intext:"admin" -inurl:"login"`)
+
+		assert.Nil(err)
+		assert.Equal(googlesearch.New().
+			Site("example.com").
+			InText("admin").
+			Exclude(googlesearch.New().InURL("login")), result, "they should be equal")
+	})
+
+	t.Run("should be stable when round tripping", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("linkedin.com").
+			Group(googlesearch.New().InText("1").Or().InText("2")).
+			Ext("(doc | pdf)").
+			Cache("www.google.com").
+			And().
+			Maps("france")
+
+		result, err := googlesearch.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.String(), result.String(), "they should be equal")
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+
+	t.Run("should normalize the OR keyword", func(t *testing.T) {
+		result, err := googlesearch.Parse(`site:a.com OR site:b.com`)
+
+		assert.Nil(err)
+		assert.Equal("site:a.com | site:b.com", result.String(), "they should be equal")
+	})
+
+	t.Run("should return syntax errors", func(t *testing.T) {
+		_, err := googlesearch.Parse(`(site:a.com intext:"x`)
+
+		assert.EqualError(err, "syntax error at offset 19: unbalanced quote")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should accept a valid request", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			Or().
+			Group(googlesearch.New().InText("1").Or().InText("2")).
+			Ext("(doc | pdf)")
+
+		assert.Nil(dork.Err())
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should accumulate errors in the fluent chain", func(t *testing.T) {
+		dork = googlesearch.New().
+			InText("admin").
+			Site("a..com").
+			Ext(".pdf")
+
+		var validationErr *query.ValidationError
+		assert.True(errors.As(dork.Err(), &validationErr), "it should be a validation error")
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidDomain), "it should be an invalid domain error")
+		assert.Equal(1, validationErr.Index, "they should be equal")
+		assert.Equal(dork.Err(), dork.Validate(), "they should be equal")
+	})
+
+	t.Run("should reject whitespaces in unquoted values", func(t *testing.T) {
+		dork = googlesearch.New().Source("bbc intext:x")
+
+		assert.True(errors.Is(dork.Validate(), query.ErrUnquotedSpace), "it should be an unquoted space error")
+		assert.EqualError(dork.Err(), "tag 0: whitespace in unquoted value: bbc intext:x")
+
+		parsed := googlesearch.FromQuery(query.Query{query.Operator{Name: query.OpSource, Value: "bbc intext:x"}})
+		assert.True(errors.Is(parsed.Validate(), query.ErrUnquotedSpace), "it should be an unquoted space error")
+	})
+
+	t.Run("should reject consecutive operators", func(t *testing.T) {
+		dork = googlesearch.New().InText("a").And().And().InText("b")
+
+		assert.True(errors.Is(dork.Err(), query.ErrDanglingOperator), "it should be a dangling operator error")
+	})
+
+	t.Run("should reject a dangling operator", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com").Or()
+
+		assert.Nil(dork.Err())
+		assert.EqualError(dork.Validate(), "tag 1: dangling operator")
+	})
+
+	t.Run("should reject an empty group", func(t *testing.T) {
+		dork = googlesearch.New().Group(googlesearch.New())
+
+		assert.True(errors.Is(dork.Validate(), query.ErrEmptyGroup), "it should be an empty group error")
+	})
+
+	t.Run("should accept allin operators with keywords", func(t *testing.T) {
+		dork = googlesearch.New().AllInTitle("admin").Plain("login")
+
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should reject allin operators combined with other operators", func(t *testing.T) {
+		dorks := []*googlesearch.GoogleSearch{
+			googlesearch.New().AllInTitle("admin").Site("example.com"),
+			googlesearch.New().Site("example.com").AllInURL("admin"),
+			googlesearch.New().AllInText("a").AllInAnchor("b"),
+			googlesearch.New().AllInTitle("admin").Not(googlesearch.New().InURL("login")),
+		}
+
+		for _, dork := range dorks {
+			var validationErr *query.ValidationError
+			err := dork.Validate()
+			assert.True(errors.As(err, &validationErr), dork.String())
+			assert.True(errors.Is(err, query.ErrNonCombinable), dork.String())
+			assert.Equal(1, validationErr.Index, "they should be equal")
+		}
+	})
+}
+
+func TestEscaping(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should strip embedded quotes by default", func(t *testing.T) {
+		dork = googlesearch.New().InText(`say "hi"`)
+
+		assert.Equal(`intext:"say hi"`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should escape embedded quotes", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithEscapePolicy(query.EscapeQuotes)).InText(`say "hi"`)
+
+		assert.Equal(`intext:"say \"hi\""`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+
+		result, err := googlesearch.Parse(dork.String())
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+
+	t.Run("should reject embedded quotes", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithEscapePolicy(query.RejectQuotes)).
+			Site("example.com").
+			InTitle(`say "hi"`)
+
+		assert.True(errors.Is(dork.Err(), query.ErrUnbalancedQuote), "it should be an unbalanced quote error")
+		assert.EqualError(dork.Validate(), "tag 1: unbalanced quote: say \"hi\"")
+	})
+
+	t.Run("should normalize new lines and control characters", func(t *testing.T) {
+		dork = googlesearch.New().InText("admin\n\tpanel\x00").Site(" example.com\n")
+
+		assert.Equal(`intext:"admin panel" site:example.com`, dork.String(), "they should be equal")
+	})
+
+	t.Run("should keep unicode and operator-like values inside quotes", func(t *testing.T) {
+		dork = googlesearch.New().InText("site:example.com -inurl:login").InTitle("mot de passe été ✓")
+
+		assert.Equal(`intext:"site:example.com -inurl:login" intitle:"mot de passe été ✓"`, dork.String(), "they should be equal")
+
+		result, err := googlesearch.Parse(dork.String())
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+}
+
+func TestExclude(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should negate each tag of a compound expression", func(t *testing.T) {
+		dork = googlesearch.New().
+			Exclude(googlesearch.New().Site("a.com").Site("b.com")).
+			Exclude(googlesearch.New().InURL("login").Or().InURL("admin"))
+
+		assert.Equal(`-site:a.com -site:b.com -inurl:"login" -inurl:"admin"`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should negate groups as a whole", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			Exclude(googlesearch.New().Group(googlesearch.New().Plain("php").Or().Plain("html")))
+
+		assert.Equal("site:example.com -(php | html)", dork.String(), "they should be equal")
+	})
+
+	t.Run("should reject empty exclusions", func(t *testing.T) {
+		dork = googlesearch.New().Exclude(googlesearch.New())
+
+		assert.True(errors.Is(dork.Err(), query.ErrEmptyGroup), "it should be an empty group error")
+		assert.Equal("", dork.String(), "they should be equal")
+	})
+
+	t.Run("should negate a single tag", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com").Not(googlesearch.New().InTitle("index of"))
+
+		assert.Equal(`site:example.com -intitle:"index of"`, dork.String(), "they should be equal")
+	})
+
+	t.Run("should reject negating multiple tags", func(t *testing.T) {
+		dork = googlesearch.New().Not(googlesearch.New().Site("a.com").Site("b.com"))
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+}
+
+func TestBaseURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should use a localized Google domain", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithGoogleDomain("co.uk")).Site("example.com")
+
+		result, err := dork.URLE()
+
+		assert.Nil(err)
+		assert.Equal("https://www.google.co.uk/search?q=site%3Aexample.com", result, "they should be equal")
+		assert.Equal("https://www.google.co.uk/search?q=site%3Aexample.com&start=10", dork.PageURL(2), "they should be equal")
+	})
+
+	t.Run("should keep parameters of the base URL", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithBaseURL("https://proxy.example.com/google?token=abc&q=x")).Site("example.com")
+
+		assert.Equal("https://proxy.example.com/google?q=site%3Aexample.com&token=abc", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should send requests to a local server", func(t *testing.T) {
+		var received url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.URL.Query()
+		}))
+		defer server.Close()
+
+		dork = googlesearch.New(googlesearch.WithBaseURL(server.URL + "/search")).Site("example.com").HostLanguage("fr")
+
+		u, err := dork.URLE()
+		assert.Nil(err)
+
+		res, err := http.Get(u)
+		if assert.Nil(err) {
+			res.Body.Close()
+		}
+		assert.Equal(dork.QueryValues(), received, "they should be equal")
+	})
+
+	t.Run("should return an error for an invalid base URL", func(t *testing.T) {
+		for _, baseURL := range []string{"://google.com", "/search", "https://"} {
+			dork = googlesearch.New(googlesearch.WithBaseURL(baseURL)).Site("example.com")
+
+			result, err := dork.URLE()
+
+			assert.True(errors.Is(err, query.ErrInvalidBaseURL), baseURL)
+			assert.Equal("", result, "they should be equal")
+			assert.Equal("", dork.URL(), "they should be equal")
+		}
+
+		for _, domain := range []string{"co uk", "", "co..uk", ".fr", "fr/evil", "-fr"} {
+			dork = googlesearch.New(googlesearch.WithGoogleDomain(domain)).Site("example.com")
+
+			_, err := dork.URLE()
+
+			assert.True(errors.Is(err, query.ErrInvalidBaseURL), domain)
+			assert.Equal("", dork.URL(), "they should be equal")
+			assert.Equal("", dork.PageURL(2), "they should be equal")
+		}
+
+		_, err := googlesearch.New(googlesearch.WithGoogleDomain(""), googlesearch.WithBaseURL("http://localhost/search")).URLE()
+		assert.Nil(err)
+	})
+}
+
+func TestDateRange(t *testing.T) {
+	assert := assertion.New(t)
+
+	from := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should use before and after operators", func(t *testing.T) {
+		dork = googlesearch.New().
+			FileType("pdf").
+			After(from).
+			Before(to)
+
+		assert.Equal(`filetype:"pdf" after:2019-06-01 before:2020-01-01`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should use a date range", func(t *testing.T) {
+		dork = googlesearch.New().Between(from, to)
+
+		assert.Equal("after:2019-06-01 before:2020-01-01", dork.String(), "they should be equal")
+		assert.Equal(dork.Query(), googlesearch.New().After(from).Before(to).Query(), "they should be equal")
+	})
+
+	t.Run("should reject a date range ending before it starts", func(t *testing.T) {
+		dork = googlesearch.New().Between(to, from)
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidDateRange), "it should be an invalid date range error")
+		assert.Equal("", dork.String(), "they should be equal")
+	})
+
+	t.Run("should validate the order of dates", func(t *testing.T) {
+		dork = googlesearch.New().Before(from).Site("example.com").After(to)
+
+		assert.Nil(dork.Err())
+		assert.EqualError(dork.Validate(), "tag 2: invalid date range")
+	})
+
+	t.Run("should parse dates", func(t *testing.T) {
+		result, err := googlesearch.Parse("after:2019 before:2020-01-01")
+
+		assert.Nil(err)
+		assert.Nil(result.Validate())
+		assert.Equal(query.Query{
+			query.Operator{Name: query.OpAfter, Value: "2019"},
+			query.Operator{Name: query.OpBefore, Value: "2020-01-01"},
+		}, result.Query(), "they should be equal")
+
+		result, err = googlesearch.Parse("after:yesterday")
+
+		assert.Nil(err)
+		assert.True(errors.Is(result.Validate(), query.ErrInvalidDate), "it should be an invalid date error")
+	})
+}
+
+func TestPrimitives(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should search for phrases around each other", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			Around("admin", 3, "password")
+
+		assert.Equal(`site:example.com "admin" AROUND(3) "password"`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should search for exact phrases and terms", func(t *testing.T) {
+		dork = googlesearch.New().
+			Phrase("admin * password").
+			Term("login").
+			Wildcard().
+			Term("panel")
+
+		assert.Equal(`"admin * password" login * panel`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should search for numeric ranges", func(t *testing.T) {
+		dork = googlesearch.New().
+			Phrase("camera").
+			Range("$100", "$500")
+
+		assert.Equal(`"camera" $100..$500`, dork.String(), "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should round trip", func(t *testing.T) {
+		dork = googlesearch.New().
+			Around("admin", 3, "password").
+			Or().
+			Group(googlesearch.New().Range("1", "10").Wildcard()).
+			Phrase("index of")
+
+		result, err := googlesearch.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+	})
+
+	t.Run("should validate primitives", func(t *testing.T) {
+		cases := []struct {
+			dork *googlesearch.GoogleSearch
+			err  error
+		}{
+			{dork: googlesearch.New().Term("two words"), err: query.ErrInvalidTerm},
+			{dork: googlesearch.New().Term(""), err: query.ErrInvalidTerm},
+			{dork: googlesearch.New().Around("a", -1, "b"), err: query.ErrInvalidDistance},
+			{dork: googlesearch.New().Range("$500", "$100"), err: query.ErrInvalidRange},
+			{dork: googlesearch.New().Range("", "10"), err: query.ErrInvalidRange},
+		}
+
+		for _, c := range cases {
+			assert.True(errors.Is(c.dork.Err(), c.err), c.dork.String())
+			assert.True(errors.Is(c.dork.Validate(), c.err), c.dork.String())
+		}
+	})
+
+	t.Run("should apply the escape policy to phrases", func(t *testing.T) {
+		dork = googlesearch.New().Phrase(`say "hi"`).Around(`"a"`, 1, "b")
+
+		assert.Equal(`"say hi" "a" AROUND(1) "b"`, dork.String(), "they should be equal")
+	})
+}
+
+func TestClone(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should clone the request", func(t *testing.T) {
+		base := googlesearch.New().Site("example.com")
+		a := base.Clone().InText("a")
+		b := base.Clone().InText("b")
+
+		assert.Equal("site:example.com", base.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"a\"", a.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"b\"", b.String(), "they should be equal")
+	})
+
+	t.Run("should clone URL parameters and errors", func(t *testing.T) {
+		base := googlesearch.New().Site("a..com").SafeSearch(googlesearch.SafeSearchActive)
+		clone := base.Clone().SafeSearch(googlesearch.SafeSearchOff)
+
+		assert.Equal(base.Err(), clone.Err(), "they should be equal")
+		assert.NotEqual(base.URL(), clone.URL(), "they should not be equal")
+	})
+
+	t.Run("should branch immutable requests", func(t *testing.T) {
+		base := googlesearch.New(googlesearch.WithImmutable()).Site("example.com")
+		a := base.InText("a").Or().InText("c")
+		b := base.InText("b").Exclude(googlesearch.New().InURL("login"))
+		c := base.Site("a..com")
+
+		assert.Equal("site:example.com", base.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"a\" | intext:\"c\"", a.String(), "they should be equal")
+		assert.Equal("site:example.com intext:\"b\" -inurl:\"login\"", b.String(), "they should be equal")
+		assert.Nil(base.Err())
+		assert.True(errors.Is(c.Err(), query.ErrInvalidDomain), "it should be an invalid domain error")
+	})
+
+	t.Run("should branch immutable URL parameters", func(t *testing.T) {
+		base := googlesearch.New(googlesearch.WithImmutable()).Site("example.com")
+		a := base.SafeSearch(googlesearch.SafeSearchOff)
+
+		assert.Equal("https://www.google.com/search?q=site%3Aexample.com", base.URL(), "they should be equal")
+		assert.NotEqual(base.URL(), a.URL(), "they should not be equal")
+	})
+}
+
+func TestConcurrency(t *testing.T) {
+	assert := assertion.New(t)
+
+	const workers = 50
+
+	t.Run("should read a shared request concurrently", func(t *testing.T) {
+		base := googlesearch.New().Site("example.com").InText("admin").SafeSearch(googlesearch.SafeSearchActive)
+		expected := base.URL()
+
+		var wg sync.WaitGroup
+		results := make([]string, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_ = base.String()
+				_ = base.QueryValues()
+				_ = base.Validate()
+				results[i] = base.URL()
+			}(i)
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			assert.Equal(expected, result, "they should be equal")
+		}
+	})
+
+	t.Run("should add tags to a shared request concurrently", func(t *testing.T) {
+		shared := googlesearch.New().Site("example.com")
+
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				shared.InText(strconv.Itoa(i)).SafeSearch(googlesearch.SafeSearchActive)
+			}(i)
+			go func() {
+				defer wg.Done()
+				_ = shared.URL()
+				_ = shared.Query()
+				_ = shared.Err()
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(shared.Query(), workers+1)
+		assert.Nil(shared.Validate())
+	})
+
+	t.Run("should branch a shared immutable request concurrently", func(t *testing.T) {
+		base := googlesearch.New(googlesearch.WithImmutable()).Site("example.com")
+
+		var wg sync.WaitGroup
+		results := make([]string, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = base.InText(strconv.Itoa(i)).Clone().Or().InURL("login").String()
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal("site:example.com", base.String(), "they should be equal")
+		for i, result := range results {
+			assert.Equal(fmt.Sprintf("site:example.com intext:\"%d\" | inurl:\"login\"", i), result, "they should be equal")
+		}
+	})
+}
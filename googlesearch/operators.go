@@ -0,0 +1,85 @@
+package googlesearch
+
+import (
+	"fmt"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// operators describes the status of Google Search operators, indexed by operator name.
+var operators = map[string]query.OperatorInfo{
+	query.OpSite:         {Name: query.OpSite, Status: query.Supported},
+	query.OpInURL:        {Name: query.OpInURL, Status: query.Supported},
+	query.OpFileType:     {Name: query.OpFileType, Status: query.Supported},
+	query.OpExt:          {Name: query.OpExt, Status: query.Supported},
+	query.OpInTitle:      {Name: query.OpInTitle, Status: query.Supported},
+	query.OpInText:       {Name: query.OpInText, Status: query.Supported},
+	query.OpAllInText:    {Name: query.OpAllInText, Status: query.Supported},
+	query.OpAllInTitle:   {Name: query.OpAllInTitle, Status: query.Supported},
+	query.OpAllInURL:     {Name: query.OpAllInURL, Status: query.Supported},
+	query.OpBefore:       {Name: query.OpBefore, Status: query.Supported},
+	query.OpAfter:        {Name: query.OpAfter, Status: query.Supported},
+	query.OpDefine:       {Name: query.OpDefine, Status: query.Supported},
+	query.OpOr:           {Name: query.OpOr, Status: query.Supported},
+	query.OpInAnchor:     {Name: query.OpInAnchor, Status: query.Degraded, Note: "results are partial"},
+	query.OpAllInAnchor:  {Name: query.OpAllInAnchor, Status: query.Degraded, Note: "results are partial"},
+	query.OpSource:       {Name: query.OpSource, Status: query.Degraded, Note: "only honored by Google News"},
+	query.OpWeather:      {Name: query.OpWeather, Status: query.Degraded, Note: "the value may be treated as a keyword"},
+	query.OpIP:           {Name: query.OpIP, Status: query.Degraded, Note: "not documented by Google"},
+	query.OpCache:        {Name: query.OpCache, Status: query.Deprecated, Since: "2024-02", Note: "Google no longer links to cached pages"},
+	query.OpInfo:         {Name: query.OpInfo, Status: query.Deprecated, Since: "2017", Note: "use site: instead"},
+	query.OpRelated:      {Name: query.OpRelated, Status: query.Deprecated, Since: "2023-07"},
+	query.OpBook:         {Name: query.OpBook, Status: query.Deprecated, Note: "use the Books result type instead"},
+	query.OpMaps:         {Name: query.OpMaps, Status: query.Deprecated, Note: "use Google Maps instead"},
+	query.OpInPostAuthor: {Name: query.OpInPostAuthor, Status: query.Deprecated, Since: "2014", Note: "Google Blog Search was retired"},
+	query.OpStocks:       {Name: query.OpStocks, Status: query.Deprecated, Note: "the value is treated as a keyword"},
+	query.OpLocation:     {Name: query.OpLocation, Status: query.Deprecated, Note: "use the CountryRestrict parameter instead"},
+	query.OpAnd:          {Name: query.OpAnd, Status: query.Deprecated, Since: "2011-10", Note: "keywords are required by default, use a phrase to match a word exactly"},
+}
+
+// WithStrictMode makes operators that Google no longer honors, such as cache, an error.
+func WithStrictMode() Option {
+	return func(e *GoogleSearch) {
+		e.strict = true
+	}
+}
+
+// Status returns the status of an operator on Google Search, such as query.OpCache or query.OpAnd.
+func Status(operator string) (query.OperatorInfo, bool) {
+	info, ok := operators[operator]
+	return info, ok
+}
+
+// Operators returns the status of every Google Search operator, indexed by operator name.
+// The returned map is a copy and can be modified.
+func Operators() map[string]query.OperatorInfo {
+	table := make(map[string]query.OperatorInfo, len(operators))
+	for name, info := range operators {
+		table[name] = info
+	}
+
+	return table
+}
+
+// Warnings returns a diagnostic for each operator of the request that Google doesn't fully honor.
+func (e *GoogleSearch) Warnings() []query.Warning {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return query.Warnings(e.nodes, operators)
+}
+
+// firstDeprecated returns the warning about the first operator of the query that Google no longer honors, if any.
+func firstDeprecated(q query.Query) (query.Warning, bool) {
+	for _, w := range query.Warnings(q, operators) {
+		if w.Operator.Status == query.Deprecated {
+			return w, true
+		}
+	}
+
+	return query.Warning{}, false
+}
+
+func errDeprecated(w query.Warning) error {
+	return fmt.Errorf("%w: %s", query.ErrDeprecatedOperator, w.Operator.Name)
+}
@@ -0,0 +1,93 @@
+package googlesearch_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestOperators(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should describe operator status", func(t *testing.T) {
+		info, ok := googlesearch.Status(query.OpCache)
+
+		assert.True(ok)
+		assert.Equal(query.Deprecated, info.Status, "they should be equal")
+		assert.Equal("2024-02", info.Since, "they should be equal")
+
+		info, ok = googlesearch.Status(query.OpSite)
+
+		assert.True(ok)
+		assert.Equal(query.Supported, info.Status, "they should be equal")
+
+		_, ok = googlesearch.Status("unknown")
+
+		assert.False(ok)
+	})
+
+	t.Run("should return a copy of the operator table", func(t *testing.T) {
+		table := googlesearch.Operators()
+
+		assert.Equal(query.Deprecated, table[query.OpCache].Status, "they should be equal")
+
+		table[query.OpCache] = query.OperatorInfo{Name: query.OpCache, Status: query.Supported}
+		info, _ := googlesearch.Status(query.OpCache)
+
+		assert.Equal(query.Deprecated, info.Status, "they should be equal")
+	})
+
+	t.Run("should not warn about supported operators", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com").Or().InURL("login")
+
+		assert.Nil(dork.Warnings())
+	})
+
+	t.Run("should warn about deprecated and degraded operators", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			Cache("example.com").
+			Group(googlesearch.New().InText("a").And().InAnchor("b"))
+
+		warnings := dork.Warnings()
+
+		assert.Len(warnings, 3)
+		assert.Equal(1, warnings[0].Index, "they should be equal")
+		assert.Equal(query.OpCache, warnings[0].Operator.Name, "they should be equal")
+		assert.Equal("tag 1: cache is deprecated since 2024-02: Google no longer links to cached pages", warnings[0].String(), "they should be equal")
+		assert.Equal(2, warnings[1].Index, "they should be equal")
+		assert.Equal(query.OpAnd, warnings[1].Operator.Name, "they should be equal")
+		assert.Equal(query.Degraded, warnings[2].Operator.Status, "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should reject deprecated operators in strict mode", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithStrictMode()).
+			Site("example.com").
+			Info("example.com")
+
+		var validationErr *query.ValidationError
+		assert.True(errors.As(dork.Err(), &validationErr), "it should be a validation error")
+		assert.True(errors.Is(dork.Err(), query.ErrDeprecatedOperator), "it should be a deprecated operator error")
+		assert.Equal(1, validationErr.Index, "they should be equal")
+		assert.Equal(dork.Err(), dork.Validate(), "they should be equal")
+	})
+
+	t.Run("should reject nested deprecated operators in strict mode", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithStrictMode()).
+			InText("a").
+			Not(googlesearch.New().Related("example.com"))
+
+		assert.EqualError(dork.Validate(), "tag 1: deprecated operator: related")
+	})
+
+	t.Run("should accept degraded operators in strict mode", func(t *testing.T) {
+		dork = googlesearch.New(googlesearch.WithStrictMode()).InAnchor("admin").Source("theguardian")
+
+		assert.Nil(dork.Validate())
+		assert.Len(dork.Warnings(), 2)
+	})
+}
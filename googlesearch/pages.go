@@ -0,0 +1,50 @@
+package googlesearch
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+const defaultNum = 10
+
+// PageURL returns the URL of the given result page, starting at 1.
+// It returns an empty string if the page or the base URL is invalid, use PageURLE to get the error.
+func (e *GoogleSearch) PageURL(page int) string {
+	u, _ := e.PageURLE(page)
+	return u
+}
+
+// PageURLE returns the URL of the given result page, starting at 1, or returns an error if the page or the base URL is invalid.
+// Pages are computed using the num parameter, or 10 results per page,
+// starting from the start parameter if it's set.
+func (e *GoogleSearch) PageURLE(page int) (string, error) {
+	if page < 1 {
+		return "", fmt.Errorf("%w: page=%d", query.ErrInvalidParameter, page)
+	}
+
+	params := e.QueryValues()
+
+	size, err := strconv.Atoi(params.Get("num"))
+	if err != nil {
+		size = defaultNum
+	}
+	offset, _ := strconv.Atoi(params.Get("start"))
+
+	if page > 1 {
+		params.Set("start", strconv.Itoa(offset+(page-1)*size))
+	}
+
+	return e.encode(params)
+}
+
+// Pages returns the URLs of the first n result pages.
+func (e *GoogleSearch) Pages(n int) []string {
+	var pages []string
+	for page := 1; page <= n; page++ {
+		pages = append(pages, e.PageURL(page))
+	}
+
+	return pages
+}
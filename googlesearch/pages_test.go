@@ -0,0 +1,58 @@
+package googlesearch_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestPages(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should return URLs of result pages", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com")
+
+		assert.Equal([]string{
+			"https://www.google.com/search?q=site%3Aexample.com",
+			"https://www.google.com/search?q=site%3Aexample.com&start=10",
+			"https://www.google.com/search?q=site%3Aexample.com&start=20",
+		}, dork.Pages(3), "they should be equal")
+		assert.Equal(dork.URL(), dork.PageURL(1), "they should be equal")
+	})
+
+	t.Run("should use num and start parameters", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com").Num(100).Start(5)
+
+		assert.Equal([]string{
+			"https://www.google.com/search?num=100&q=site%3Aexample.com&start=5",
+			"https://www.google.com/search?num=100&q=site%3Aexample.com&start=105",
+		}, dork.Pages(2), "they should be equal")
+	})
+
+	t.Run("should return no pages", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com")
+
+		assert.Empty(dork.Pages(0))
+	})
+
+	t.Run("should reject pages before the first one", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com")
+
+		for _, page := range []int{0, -1} {
+			_, err := dork.PageURLE(page)
+
+			assert.True(errors.Is(err, query.ErrInvalidParameter), "it should be an invalid parameter error")
+			assert.Equal("", dork.PageURL(page), "they should be equal")
+		}
+	})
+
+	t.Run("should not modify the request", func(t *testing.T) {
+		dork = googlesearch.New().Site("example.com")
+		dork.Pages(5)
+
+		assert.Equal("https://www.google.com/search?q=site%3Aexample.com", dork.URL(), "they should be equal")
+	})
+}
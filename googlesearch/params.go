@@ -0,0 +1,258 @@
+package googlesearch
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// SafeSearch is the safe search mode, used as the "safe" URL parameter.
+type SafeSearch string
+
+// Safe search modes
+const (
+	SafeSearchActive SafeSearch = "active"
+	SafeSearchOff    SafeSearch = "off"
+)
+
+// TimeRange restricts results to a recent period, used as the "tbs" URL parameter.
+type TimeRange string
+
+// Time ranges
+const (
+	PastHour  TimeRange = "h"
+	PastDay   TimeRange = "d"
+	PastWeek  TimeRange = "w"
+	PastMonth TimeRange = "m"
+	PastYear  TimeRange = "y"
+)
+
+// ResultType is the kind of results to search for, used as the "tbm" URL parameter.
+type ResultType string
+
+// Result types
+const (
+	Images   ResultType = "isch"
+	News     ResultType = "nws"
+	Videos   ResultType = "vid"
+	Books    ResultType = "bks"
+	Shopping ResultType = "shop"
+)
+
+const (
+	maxNum     = 100
+	dateFormat = "1/2/2006"
+)
+
+var (
+	languageCode = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z]{2,4})?$`)
+	countryCode  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+)
+
+func (e *GoogleSearch) set(param string, value string) *GoogleSearch {
+	e = e.next()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.params == nil {
+		e.params = url.Values{}
+	}
+	e.params.Set(param, value)
+	return e
+}
+
+func (e *GoogleSearch) invalid(param string, value interface{}) *GoogleSearch {
+	e = e.next()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err == nil {
+		e.err = fmt.Errorf("%w: %s=%v", query.ErrInvalidParameter, param, value)
+	}
+	return e
+}
+
+// setters maps each URL parameter to the method producing it, so that the parameters of a document
+// are validated the same way as the ones of a request.
+var setters = map[string]func(e *GoogleSearch, value string) *GoogleSearch{
+	"hl": (*GoogleSearch).HostLanguage,
+	"gl": (*GoogleSearch).GeoLocation,
+	"lr": func(e *GoogleSearch, value string) *GoogleSearch {
+		if !strings.HasPrefix(value, "lang_") {
+			return e.invalid("lr", value)
+		}
+		return e.LanguageRestrict(strings.TrimPrefix(value, "lang_"))
+	},
+	"cr": func(e *GoogleSearch, value string) *GoogleSearch {
+		if !strings.HasPrefix(value, "country") {
+			return e.invalid("cr", value)
+		}
+		return e.CountryRestrict(strings.TrimPrefix(value, "country"))
+	},
+	"num": func(e *GoogleSearch, value string) *GoogleSearch {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return e.invalid("num", value)
+		}
+		return e.Num(n)
+	},
+	"start": func(e *GoogleSearch, value string) *GoogleSearch {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return e.invalid("start", value)
+		}
+		return e.Start(n)
+	},
+	"safe": func(e *GoogleSearch, value string) *GoogleSearch {
+		return e.SafeSearch(SafeSearch(value))
+	},
+	"filter": func(e *GoogleSearch, value string) *GoogleSearch {
+		if value != "0" && value != "1" {
+			return e.invalid("filter", value)
+		}
+		return e.Filter(value == "1")
+	},
+	"tbs": setTBS,
+	"tbm": func(e *GoogleSearch, value string) *GoogleSearch {
+		return e.ResultType(ResultType(value))
+	},
+}
+
+// setTBS sets the tbs parameter from either a time range such as qdr:w, or a date range such as cdr:1,cd_min:1/2/2020,cd_max:3/4/2020.
+func setTBS(e *GoogleSearch, value string) *GoogleSearch {
+	if strings.HasPrefix(value, "qdr:") {
+		return e.TimeRange(TimeRange(strings.TrimPrefix(value, "qdr:")))
+	}
+
+	fields := strings.Split(value, ",")
+	if len(fields) != 3 || fields[0] != "cdr:1" || !strings.HasPrefix(fields[1], "cd_min:") || !strings.HasPrefix(fields[2], "cd_max:") {
+		return e.invalid("tbs", value)
+	}
+	from, fromErr := time.Parse(dateFormat, strings.TrimPrefix(fields[1], "cd_min:"))
+	to, toErr := time.Parse(dateFormat, strings.TrimPrefix(fields[2], "cd_max:"))
+	if fromErr != nil || toErr != nil {
+		return e.invalid("tbs", value)
+	}
+
+	return e.DateRange(from, to)
+}
+
+// loadParams checks the URL parameters of a document and returns them as the setters would have set them.
+func loadParams(params url.Values) (url.Values, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	e := &GoogleSearch{}
+	for _, name := range names {
+		set, ok := setters[name]
+		if !ok || len(params[name]) != 1 {
+			return nil, fmt.Errorf("%w: %s=%s", query.ErrInvalidParameter, name, strings.Join(params[name], ","))
+		}
+		if e = set(e, params[name][0]); e.err != nil {
+			return nil, e.err
+		}
+	}
+
+	return e.params, nil
+}
+
+// HostLanguage sets the language of the user interface, such as "fr" or "zh-CN" (hl parameter).
+func (e *GoogleSearch) HostLanguage(lang string) *GoogleSearch {
+	if !languageCode.MatchString(lang) {
+		return e.invalid("hl", lang)
+	}
+	return e.set("hl", lang)
+}
+
+// GeoLocation boosts results from a country, using its ISO 3166-1 code such as "fr" (gl parameter).
+func (e *GoogleSearch) GeoLocation(country string) *GoogleSearch {
+	if !countryCode.MatchString(country) {
+		return e.invalid("gl", country)
+	}
+	return e.set("gl", strings.ToLower(country))
+}
+
+// LanguageRestrict restricts results to documents written in a language, such as "fr" or "zh-TW" (lr parameter).
+func (e *GoogleSearch) LanguageRestrict(lang string) *GoogleSearch {
+	if !languageCode.MatchString(lang) {
+		return e.invalid("lr", lang)
+	}
+	return e.set("lr", "lang_"+lang)
+}
+
+// CountryRestrict restricts results to documents originating in a country,
+// using its ISO 3166-1 code such as "fr" (cr parameter).
+func (e *GoogleSearch) CountryRestrict(country string) *GoogleSearch {
+	if !countryCode.MatchString(country) {
+		return e.invalid("cr", country)
+	}
+	return e.set("cr", "country"+strings.ToUpper(country))
+}
+
+// Num sets the number of results per page, between 1 and 100 (num parameter).
+func (e *GoogleSearch) Num(n int) *GoogleSearch {
+	if n < 1 || n > maxNum {
+		return e.invalid("num", n)
+	}
+	return e.set("num", strconv.Itoa(n))
+}
+
+// Start sets the index of the first result to return, starting at 0 (start parameter).
+func (e *GoogleSearch) Start(n int) *GoogleSearch {
+	if n < 0 {
+		return e.invalid("start", n)
+	}
+	return e.set("start", strconv.Itoa(n))
+}
+
+// SafeSearch sets the safe search mode (safe parameter).
+func (e *GoogleSearch) SafeSearch(mode SafeSearch) *GoogleSearch {
+	switch mode {
+	case SafeSearchActive, SafeSearchOff:
+		return e.set("safe", string(mode))
+	}
+	return e.invalid("safe", mode)
+}
+
+// Filter enables or disables the filters removing similar results and results from the same site (filter parameter).
+func (e *GoogleSearch) Filter(enabled bool) *GoogleSearch {
+	if enabled {
+		return e.set("filter", "1")
+	}
+	return e.set("filter", "0")
+}
+
+// TimeRange restricts results to a recent period (tbs parameter). It replaces any date range.
+func (e *GoogleSearch) TimeRange(r TimeRange) *GoogleSearch {
+	switch r {
+	case PastHour, PastDay, PastWeek, PastMonth, PastYear:
+		return e.set("tbs", "qdr:"+string(r))
+	}
+	return e.invalid("tbs", r)
+}
+
+// DateRange restricts results to a custom period (tbs parameter). It replaces any time range.
+func (e *GoogleSearch) DateRange(from, to time.Time) *GoogleSearch {
+	if to.Before(from) {
+		return e.invalid("tbs", fmt.Sprintf("%s..%s", from.Format("2006-01-02"), to.Format("2006-01-02")))
+	}
+	return e.set("tbs", fmt.Sprintf("cdr:1,cd_min:%s,cd_max:%s", from.Format(dateFormat), to.Format(dateFormat)))
+}
+
+// ResultType restricts results to a kind of content, such as images or news (tbm parameter).
+func (e *GoogleSearch) ResultType(t ResultType) *GoogleSearch {
+	switch t {
+	case Images, News, Videos, Books, Shopping:
+		return e.set("tbm", string(t))
+	}
+	return e.invalid("tbm", t)
+}
@@ -0,0 +1,86 @@
+package googlesearch_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestParams(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should set URL parameters", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			HostLanguage("fr").
+			GeoLocation("FR").
+			LanguageRestrict("fr").
+			CountryRestrict("fr").
+			Num(100).
+			Start(20).
+			SafeSearch(googlesearch.SafeSearchOff).
+			Filter(false).
+			TimeRange(googlesearch.PastWeek).
+			ResultType(googlesearch.News)
+
+		assert.Nil(dork.Err())
+		assert.Equal(url.Values{
+			"q":      []string{"site:example.com"},
+			"hl":     []string{"fr"},
+			"gl":     []string{"fr"},
+			"lr":     []string{"lang_fr"},
+			"cr":     []string{"countryFR"},
+			"num":    []string{"100"},
+			"start":  []string{"20"},
+			"safe":   []string{"off"},
+			"filter": []string{"0"},
+			"tbs":    []string{"qdr:w"},
+			"tbm":    []string{"nws"},
+		}, dork.QueryValues(), "they should be equal")
+	})
+
+	t.Run("should reflect URL parameters in the URL", func(t *testing.T) {
+		dork = googlesearch.New().
+			Site("example.com").
+			HostLanguage("zh-CN").
+			ResultType(googlesearch.Images).
+			SafeSearch(googlesearch.SafeSearchActive)
+
+		assert.Equal("https://www.google.com/search?hl=zh-CN&q=site%3Aexample.com&safe=active&tbm=isch", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should set a custom date range", func(t *testing.T) {
+		dork = googlesearch.New().
+			TimeRange(googlesearch.PastYear).
+			DateRange(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal("cdr:1,cd_min:1/2/2020,cd_max:12/31/2020", dork.QueryValues().Get("tbs"), "they should be equal")
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		cases := map[string]*googlesearch.GoogleSearch{
+			"hl=french":                  googlesearch.New().HostLanguage("french"),
+			"gl=fra":                     googlesearch.New().GeoLocation("fra"),
+			"lr=":                        googlesearch.New().LanguageRestrict(""),
+			"cr=1":                       googlesearch.New().CountryRestrict("1"),
+			"num=101":                    googlesearch.New().Num(101),
+			"num=0":                      googlesearch.New().Num(0),
+			"start=-1":                   googlesearch.New().Start(-1),
+			"safe=strict":                googlesearch.New().SafeSearch("strict"),
+			"tbs=s":                      googlesearch.New().TimeRange("s"),
+			"tbm=maps":                   googlesearch.New().ResultType("maps"),
+			"tbs=2020-02-01..2020-01-01": googlesearch.New().DateRange(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+
+		for expected, dork := range cases {
+			assert.True(errors.Is(dork.Err(), query.ErrInvalidParameter), expected)
+			assert.EqualError(dork.Err(), "invalid parameter: "+expected)
+			assert.Equal(url.Values{"q": []string{""}}, dork.QueryValues(), expected)
+		}
+	})
+}
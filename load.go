@@ -0,0 +1,62 @@
+package dorkgen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/searxng"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedEngine is returned when loading a document saved for a search engine that can't be saved as a document.
+var ErrUnsupportedEngine = errors.New("search engine doesn't support documents")
+
+// FromDocument creates a dork from a saved document, using the search engine it was saved for.
+// Only Google Search and DuckDuckGo dorks can be saved as documents, other search engines return ErrUnsupportedEngine.
+func FromDocument(doc query.Document) (Engine, error) {
+	var e Engine
+	var err error
+
+	switch doc.Engine {
+	case googlesearch.EngineName:
+		e, err = googlesearch.FromDocument(doc)
+	case duckduckgo.EngineName:
+		e, err = duckduckgo.FromDocument(doc)
+	default:
+		if _, ok := targets[doc.Engine]; ok || doc.Engine == searxng.EngineName {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedEngine, doc.Engine)
+		}
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEngine, doc.Engine)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// LoadJSON creates a dork from a JSON document, such as one produced by json.Marshal.
+func LoadJSON(data []byte) (Engine, error) {
+	var doc query.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return FromDocument(doc)
+}
+
+// LoadYAML creates a dork from a YAML document, such as a configuration file.
+// The document uses the same fields as the JSON one.
+func LoadYAML(data []byte) (Engine, error) {
+	var doc query.Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return FromDocument(doc)
+}
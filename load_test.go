@@ -0,0 +1,82 @@
+package dorkgen
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestLoad(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should load a YAML document", func(t *testing.T) {
+		data := []byte(`
+engine: google
+tags:
+  - type: operator
+    operator: site
+    value: example.com
+  - type: or
+  - type: group
+    tags:
+      - type: operator
+        operator: intext
+        value: admin
+        quoted: true
+  - type: not
+    tag:
+      type: operator
+      operator: ext
+      value: pdf
+params:
+  safe: [active]
+`)
+
+		result, err := LoadYAML(data)
+
+		assert.Nil(err)
+		assert.IsType(&googlesearch.GoogleSearch{}, result, "they should be equal")
+		assert.Equal(`site:example.com | (intext:"admin") -ext:pdf`, result.String(), "they should be equal")
+		assert.Equal("active", result.QueryValues().Get("safe"), "they should be equal")
+	})
+
+	t.Run("should load the same dork from JSON and YAML", func(t *testing.T) {
+		dork := NewDuckDuckGo().Site("example.com").InTitle("admin").Region("fr-fr").Bang("g")
+		data, _ := json.Marshal(dork)
+
+		fromJSON, err := LoadJSON(data)
+		assert.Nil(err)
+
+		fromYAML, err := LoadYAML([]byte("engine: duckduckgo\nbang: g\nparams:\n  kl: [fr-fr]\ntags:\n" +
+			"  - {type: operator, operator: site, value: example.com}\n" +
+			"  - {type: operator, operator: intitle, value: admin, quoted: true}\n"))
+		assert.Nil(err)
+
+		again, _ := json.Marshal(fromYAML)
+		assert.IsType(&duckduckgo.DuckDuckGo{}, fromJSON, "they should be equal")
+		assert.Equal(string(data), string(again), "they should be equal")
+		assert.Equal(dork.URL(), fromJSON.URL(), "they should be equal")
+	})
+
+	t.Run("should reject unknown and unsupported engines", func(t *testing.T) {
+		_, err := FromDocument(query.Document{Engine: "altavista"})
+		assert.True(errors.Is(err, ErrUnknownEngine), "it should be an unknown engine error")
+
+		for _, name := range []string{"bing", "searxng"} {
+			_, err = FromDocument(query.Document{Engine: name})
+			assert.True(errors.Is(err, ErrUnsupportedEngine), "it should be an unsupported engine error")
+			assert.False(errors.Is(err, ErrUnknownEngine), "it should not be an unknown engine error")
+		}
+
+		_, err = LoadYAML([]byte("engine: google\ntags:\n  - type: unknown\n"))
+		assert.True(errors.Is(err, query.ErrInvalidTag), "it should be an invalid tag error")
+
+		_, err = LoadJSON([]byte("{"))
+		assert.NotNil(err)
+	})
+}
@@ -0,0 +1,163 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Types of the encoded tags.
+const (
+	TagTerm     = "term"
+	TagPhrase   = "phrase"
+	TagOperator = "operator"
+	TagAnd      = "and"
+	TagOr       = "or"
+	TagNot      = "not"
+	TagGroup    = "group"
+	TagAround   = "around"
+	TagWildcard = "wildcard"
+	TagRange    = "range"
+	TagRegex    = "regex"
+)
+
+// Document is the stable JSON and YAML representation of a dork.
+type Document struct {
+	// Engine is the name of the search engine, such as "google"
+	Engine string `json:"engine" yaml:"engine"`
+	Tags   []Tag  `json:"tags" yaml:"tags"`
+	// Params holds the URL parameters of the request
+	Params url.Values `json:"params,omitempty" yaml:"params,omitempty"`
+	// Bang is the !bang of DuckDuckGo requests
+	Bang string `json:"bang,omitempty" yaml:"bang,omitempty"`
+}
+
+// Tag is the encoded form of a node. Fields depend on the type of the tag.
+type Tag struct {
+	Type string `json:"type" yaml:"type"`
+	// Operator and Value are used by operators
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`
+	Quoted   bool   `json:"quoted,omitempty" yaml:"quoted,omitempty"`
+	// Text is used by terms, phrases and regular expressions
+	Text string `json:"text,omitempty" yaml:"text,omitempty"`
+	// Tags is used by groups
+	Tags []Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Tag is used by exclusions
+	Tag *Tag `json:"tag,omitempty" yaml:"tag,omitempty"`
+	// Left, Right and Distance are used by the AROUND operator
+	Left     *Tag `json:"left,omitempty" yaml:"left,omitempty"`
+	Right    *Tag `json:"right,omitempty" yaml:"right,omitempty"`
+	Distance int  `json:"distance,omitempty" yaml:"distance,omitempty"`
+	// Low and High are used by ranges
+	Low  string `json:"low,omitempty" yaml:"low,omitempty"`
+	High string `json:"high,omitempty" yaml:"high,omitempty"`
+}
+
+// Encode converts a query to its encoded form.
+func Encode(q Query) []Tag {
+	tags := make([]Tag, 0, len(q))
+	for _, n := range q {
+		tags = append(tags, encodeNode(n))
+	}
+
+	return tags
+}
+
+func encodeNode(n Node) Tag {
+	switch v := n.(type) {
+	case Term:
+		return Tag{Type: TagTerm, Text: v.Text}
+	case Phrase:
+		return Tag{Type: TagPhrase, Text: v.Text}
+	case Operator:
+		return Tag{Type: TagOperator, Operator: v.Name, Value: v.Value, Quoted: v.Quoted}
+	case And:
+		return Tag{Type: TagAnd}
+	case Or:
+		return Tag{Type: TagOr}
+	case Not:
+		t := encodeNode(v.Node)
+		return Tag{Type: TagNot, Tag: &t}
+	case Group:
+		return Tag{Type: TagGroup, Tags: Encode(v.Nodes)}
+	case Around:
+		left, right := encodeNode(v.Left), encodeNode(v.Right)
+		return Tag{Type: TagAround, Left: &left, Right: &right, Distance: v.Distance}
+	case Wildcard:
+		return Tag{Type: TagWildcard}
+	case Range:
+		return Tag{Type: TagRange, Low: v.Low, High: v.High}
+	case Regex:
+		return Tag{Type: TagRegex, Text: v.Pattern}
+	}
+
+	return Tag{}
+}
+
+// Decode converts encoded tags back to a query.
+func Decode(tags []Tag) (Query, error) {
+	q := make(Query, 0, len(tags))
+	for i, t := range tags {
+		n, err := decodeTag(t)
+		if err != nil {
+			return nil, &ValidationError{Index: i, Err: err}
+		}
+		q = append(q, n)
+	}
+
+	return q, nil
+}
+
+func decodeTag(t Tag) (Node, error) {
+	switch t.Type {
+	case TagTerm:
+		return Term{Text: t.Text}, nil
+	case TagPhrase:
+		return Phrase{Text: t.Text}, nil
+	case TagOperator:
+		if t.Operator == "" {
+			return nil, fmt.Errorf("%w: operator without name", ErrInvalidTag)
+		}
+		return Operator{Name: t.Operator, Value: t.Value, Quoted: t.Quoted}, nil
+	case TagAnd:
+		return And{}, nil
+	case TagOr:
+		return Or{}, nil
+	case TagNot:
+		if t.Tag == nil {
+			return nil, fmt.Errorf("%w: exclusion without tag", ErrInvalidTag)
+		}
+		n, err := decodeTag(*t.Tag)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: n}, nil
+	case TagGroup:
+		nodes, err := Decode(t.Tags)
+		if err != nil {
+			return nil, err
+		}
+		return Group{Nodes: nodes}, nil
+	case TagAround:
+		if t.Left == nil || t.Right == nil {
+			return nil, fmt.Errorf("%w: around without both operands", ErrInvalidTag)
+		}
+		left, err := decodeTag(*t.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := decodeTag(*t.Right)
+		if err != nil {
+			return nil, err
+		}
+		return Around{Left: left, Right: right, Distance: t.Distance}, nil
+	case TagWildcard:
+		return Wildcard{}, nil
+	case TagRange:
+		return Range{Low: t.Low, High: t.High}, nil
+	case TagRegex:
+		return Regex{Pattern: t.Text}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidTag, t.Type)
+}
@@ -0,0 +1,59 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestEncode(t *testing.T) {
+	assert := assertion.New(t)
+
+	q := query.Query{
+		query.Operator{Name: query.OpSite, Value: "example.com"},
+		query.Or{},
+		query.Group{Nodes: query.Query{query.Term{Text: "a"}, query.And{}, query.Phrase{Text: "b c"}}},
+		query.Not{Node: query.Operator{Name: query.OpInURL, Value: "login", Quoted: true}},
+		query.Around{Left: query.Phrase{Text: "a"}, Right: query.Phrase{Text: "b"}, Distance: 3},
+		query.Wildcard{},
+		query.Range{Low: "1", High: "2"},
+		query.Regex{Pattern: `key="`},
+	}
+
+	t.Run("should encode typed tags", func(t *testing.T) {
+		tags := query.Encode(q)
+
+		assert.Equal(query.Tag{Type: query.TagOperator, Operator: query.OpSite, Value: "example.com"}, tags[0], "they should be equal")
+		assert.Equal(query.Tag{Type: query.TagOr}, tags[1], "they should be equal")
+		assert.Equal(query.TagGroup, tags[2].Type, "they should be equal")
+		assert.Len(tags[2].Tags, 3)
+		assert.Equal(&query.Tag{Type: query.TagOperator, Operator: query.OpInURL, Value: "login", Quoted: true}, tags[3].Tag, "they should be equal")
+		assert.Equal(3, tags[4].Distance, "they should be equal")
+		assert.Equal(query.Tag{Type: query.TagRegex, Text: `key="`}, tags[7], "they should be equal")
+	})
+
+	t.Run("should decode encoded tags", func(t *testing.T) {
+		result, err := query.Decode(query.Encode(q))
+
+		assert.Nil(err)
+		assert.Equal(q, result, "they should be equal")
+	})
+
+	t.Run("should reject invalid tags", func(t *testing.T) {
+		cases := [][]query.Tag{
+			{{Type: "unknown"}},
+			{{Type: query.TagOperator}},
+			{{Type: query.TagNot}},
+			{{Type: query.TagAround, Left: &query.Tag{Type: query.TagWildcard}}},
+			{{Type: query.TagGroup, Tags: []query.Tag{{Type: query.TagNot, Tag: &query.Tag{}}}}},
+		}
+
+		for _, tags := range cases {
+			_, err := query.Decode(tags)
+
+			assert.True(errors.Is(err, query.ErrInvalidTag), err.Error())
+		}
+	})
+}
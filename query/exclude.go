@@ -0,0 +1,66 @@
+package query
+
+// Exclude negates each term of the query, so results matching any of them are excluded.
+// AND and OR operators are dropped, since they can't be negated term by term.
+// Groups are negated as a whole if negateGroups is true, otherwise an ErrInvalidExclusion error is returned.
+func Exclude(q Query, negateGroups bool) (Query, error) {
+	if len(q) == 0 {
+		return nil, ErrEmptyGroup
+	}
+
+	result := make(Query, 0, len(q))
+	for _, n := range q {
+		switch v := n.(type) {
+		case And, Or:
+			continue
+		case Not:
+			return nil, errInvalidExclusion("tag is already excluded")
+		case Group:
+			if !negateGroups {
+				return nil, errInvalidExclusion("groups can't be excluded")
+			}
+			if len(v.Nodes) == 0 {
+				return nil, ErrEmptyGroup
+			}
+		}
+		result = append(result, Not{Node: n})
+	}
+
+	return result, nil
+}
+
+// ValidateExclusions checks no group of the query is excluded as a whole, for engines that don't support it,
+// and returns a *ValidationError for the first tag breaking the rule.
+func ValidateExclusions(q Query) error {
+	for i, n := range q {
+		excluded := false
+		Walk(Query{n}, func(n Node) bool {
+			if v, ok := n.(Not); ok {
+				if _, ok := v.Node.(Group); ok {
+					excluded = true
+				}
+			}
+			return !excluded
+		})
+
+		if excluded {
+			return &ValidationError{Index: i, Err: errInvalidExclusion("groups can't be excluded")}
+		}
+	}
+
+	return nil
+}
+
+// Negate negates a query made of a single tag. Groups are handled the same way as Exclude.
+func Negate(q Query, negateGroups bool) (Node, error) {
+	if len(q) != 1 || isConnective(q[0]) {
+		return nil, errInvalidExclusion("a single tag is expected")
+	}
+
+	nodes, err := Exclude(q, negateGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes[0], nil
+}
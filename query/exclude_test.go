@@ -0,0 +1,79 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestExclude(t *testing.T) {
+	assert := assertion.New(t)
+
+	a := query.Operator{Name: query.OpSite, Value: "a.com"}
+	b := query.Operator{Name: query.OpSite, Value: "b.com"}
+	group := query.Group{Nodes: query.Query{a, query.Or{}, b}}
+
+	t.Run("should negate each term", func(t *testing.T) {
+		result, err := query.Exclude(query.Query{a, query.Or{}, b, query.And{}, query.Term{Text: "php"}}, false)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Not{Node: a},
+			query.Not{Node: b},
+			query.Not{Node: query.Term{Text: "php"}},
+		}, result, "they should be equal")
+	})
+
+	t.Run("should negate groups as a whole", func(t *testing.T) {
+		result, err := query.Exclude(query.Query{group}, true)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{query.Not{Node: group}}, result, "they should be equal")
+	})
+
+	t.Run("should reject groups", func(t *testing.T) {
+		_, err := query.Exclude(query.Query{a, group}, false)
+
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+
+	t.Run("should find excluded groups", func(t *testing.T) {
+		assert.Nil(query.ValidateExclusions(query.Query{a, query.Not{Node: b}, group}))
+
+		err := query.ValidateExclusions(query.Query{a, query.Group{Nodes: query.Query{b, query.Not{Node: group}}}})
+
+		var validationErr *query.ValidationError
+		if assert.True(errors.As(err, &validationErr), "it should be a validation error") {
+			assert.Equal(1, validationErr.Index, "they should be equal")
+			assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+		}
+	})
+
+	t.Run("should reject excluded and empty tags", func(t *testing.T) {
+		_, err := query.Exclude(query.Query{query.Not{Node: a}}, true)
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+
+		_, err = query.Exclude(nil, true)
+		assert.Equal(query.ErrEmptyGroup, err, "they should be equal")
+
+		_, err = query.Exclude(query.Query{query.Group{}}, true)
+		assert.Equal(query.ErrEmptyGroup, err, "they should be equal")
+	})
+
+	t.Run("should negate a single tag", func(t *testing.T) {
+		result, err := query.Negate(query.Query{a}, false)
+
+		assert.Nil(err)
+		assert.Equal(query.Not{Node: a}, result, "they should be equal")
+	})
+
+	t.Run("should reject more than a single tag", func(t *testing.T) {
+		for _, q := range []query.Query{nil, {a, b}, {query.Or{}}} {
+			_, err := query.Negate(q, true)
+
+			assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+		}
+	})
+}
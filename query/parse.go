@@ -0,0 +1,308 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SyntaxError is returned when a dork string can't be parsed.
+type SyntaxError struct {
+	// Offset is the position of the error in the input, in bytes
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// Parse converts a dork string into a query tree, using the syntax table to recognize operators.
+// Both the syntax's own AND and OR operators and the "AND" and "OR" keywords are recognized.
+// Words using an unknown operator prefix are kept as terms.
+func (s *Syntax) Parse(str string) (Query, error) {
+	p := &parser{syntax: s, input: str, prefixes: make(map[string]string, len(s.Operators))}
+	for name, prefix := range s.Operators {
+		p.prefixes[prefix] = name
+	}
+
+	q, err := p.parseQuery(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+type parser struct {
+	syntax   *Syntax
+	input    string
+	pos      int
+	prefixes map[string]string
+}
+
+func (p *parser) parseQuery(depth int) (Query, error) {
+	var q Query
+
+	for {
+		p.skipSpaces()
+
+		if p.eof() {
+			if depth > 0 {
+				return nil, &SyntaxError{Offset: p.pos, Msg: "missing closing parenthesis"}
+			}
+			return q, nil
+		}
+
+		if p.peek() == ')' {
+			if depth == 0 {
+				return nil, &SyntaxError{Offset: p.pos, Msg: "unexpected closing parenthesis"}
+			}
+			p.pos++
+			return q, nil
+		}
+
+		start := p.pos
+		n, err := p.parseNode(depth)
+		if err != nil {
+			return nil, err
+		}
+
+		if a, ok := n.(aroundOperator); ok {
+			if len(q) == 0 || isConnective(q[len(q)-1]) {
+				return nil, &SyntaxError{Offset: start, Msg: "missing left operand of " + p.syntax.around()}
+			}
+
+			p.skipSpaces()
+			if p.eof() || p.peek() == ')' {
+				return nil, &SyntaxError{Offset: p.pos, Msg: "missing right operand of " + p.syntax.around()}
+			}
+			offset := p.pos
+			right, err := p.parseNode(depth)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := right.(aroundOperator); ok || isConnective(right) {
+				return nil, &SyntaxError{Offset: offset, Msg: "missing right operand of " + p.syntax.around()}
+			}
+
+			n = Around{Left: q[len(q)-1], Right: right, Distance: a.distance}
+			q = q[:len(q)-1]
+		}
+
+		q = append(q, n)
+	}
+}
+
+// aroundOperator is a placeholder for the proximity operator, until both of its operands are parsed.
+type aroundOperator struct {
+	distance int
+}
+
+func (aroundOperator) node() {}
+
+func (p *parser) parseNode(depth int) (Node, error) {
+	start := p.pos
+
+	if p.syntax.Not != "" && strings.HasPrefix(p.input[p.pos:], p.syntax.Not) {
+		next := p.pos + len(p.syntax.Not)
+		if next < len(p.input) && !isSpace(p.input[next]) && p.input[next] != ')' {
+			p.pos = next
+			n, err := p.parseNode(depth)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := n.(aroundOperator); ok {
+				return nil, &SyntaxError{Offset: next, Msg: "unexpected " + p.syntax.around()}
+			}
+			return Not{Node: n}, nil
+		}
+	}
+
+	if p.syntax.Regex != "" && strings.HasPrefix(p.input[p.pos:], p.syntax.Regex) {
+		return p.parseRegex()
+	}
+
+	switch p.peek() {
+	case '(':
+		p.pos++
+		nodes, err := p.parseQuery(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Group{Nodes: nodes}, nil
+	case '"':
+		text, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return Phrase{Text: text}, nil
+	}
+
+	word := p.parseWord()
+	switch word {
+	case p.syntax.Or, "OR":
+		return Or{}, nil
+	case p.syntax.And, "AND":
+		return And{}, nil
+	case "*":
+		return Wildcard{}, nil
+	case p.syntax.around():
+		if !p.eof() && p.peek() == '(' {
+			return p.parseAround(start)
+		}
+	}
+
+	if i := strings.Index(word, ":"); i >= 0 {
+		if name, ok := p.prefixes[word[:i+1]]; ok {
+			return p.parseOperator(name, start+i+1)
+		}
+	}
+
+	if i := strings.Index(word, ".."); i > 0 && i+2 < len(word) && (p.eof() || p.peek() != '"') {
+		low, high := word[:i], word[i+2:]
+		if strings.IndexFunc(low, unicode.IsDigit) >= 0 && strings.IndexFunc(high, unicode.IsDigit) >= 0 {
+			return Range{Low: low, High: high}, nil
+		}
+	}
+
+	return Term{Text: p.parseRest(start)}, nil
+}
+
+// parseOperator reads the value of an operator starting at the given offset.
+func (p *parser) parseOperator(name string, offset int) (Node, error) {
+	p.pos = offset
+
+	if p.eof() {
+		return Operator{Name: name}, nil
+	}
+
+	switch p.peek() {
+	case '"':
+		value, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return Operator{Name: name, Value: value, Quoted: true}, nil
+	case '(':
+		value, err := p.parseParenthesized()
+		if err != nil {
+			return nil, err
+		}
+		return Operator{Name: name, Value: value}, nil
+	}
+
+	return Operator{Name: name, Value: p.parseWord()}, nil
+}
+
+// parseAround reads the distance of the proximity operator, such as AROUND(3).
+func (p *parser) parseAround(start int) (Node, error) {
+	value, err := p.parseParenthesized()
+	if err != nil {
+		return nil, err
+	}
+
+	distance, err := strconv.Atoi(strings.TrimSpace(value[1 : len(value)-1]))
+	if err != nil {
+		return nil, &SyntaxError{Offset: start, Msg: "invalid distance " + value}
+	}
+
+	return aroundOperator{distance: distance}, nil
+}
+
+// parseRest reads a term starting at the given offset, including quoted parts glued to it.
+func (p *parser) parseRest(start int) string {
+	for !p.eof() && p.peek() == '"' {
+		end := strings.IndexByte(p.input[p.pos+1:], '"')
+		if end < 0 {
+			break
+		}
+		p.pos += end + 2
+		p.parseWord()
+	}
+
+	return p.input[start:p.pos]
+}
+
+// parseRegex reads a regular expression between delimiters, including spaces, parentheses and quotes.
+// Delimiters escaped with a backslash are part of the pattern.
+func (p *parser) parseRegex() (Node, error) {
+	start := p.pos
+	delim := p.syntax.Regex
+
+	for i := start + len(delim); i < len(p.input); i++ {
+		if p.input[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(p.input[i:], delim) {
+			p.pos = i + len(delim)
+			return Regex{Pattern: p.input[start+len(delim) : i]}, nil
+		}
+	}
+
+	return nil, &SyntaxError{Offset: start, Msg: "unterminated regular expression"}
+}
+
+// parseQuoted reads a value between double quotes. Quotes escaped with a backslash are part of the value.
+func (p *parser) parseQuoted() (string, error) {
+	start := p.pos
+
+	for i := start + 1; i < len(p.input); i++ {
+		if p.input[i] == '"' && p.input[i-1] != '\\' {
+			p.pos = i + 1
+			return p.input[start+1 : i], nil
+		}
+	}
+
+	return "", &SyntaxError{Offset: start, Msg: "unbalanced quote"}
+}
+
+func (p *parser) parseParenthesized() (string, error) {
+	start := p.pos
+	depth := 0
+
+	for ; p.pos < len(p.input); p.pos++ {
+		switch p.input[p.pos] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return p.input[start:p.pos], nil
+			}
+		}
+	}
+
+	return "", &SyntaxError{Offset: start, Msg: "missing closing parenthesis"}
+}
+
+// parseWord reads characters until a space, a quote or a parenthesis.
+func (p *parser) parseWord() string {
+	start := p.pos
+	for !p.eof() && !isSpace(p.peek()) && !strings.ContainsRune("()\"", rune(p.peek())) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	return p.input[p.pos]
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func isSpace(c byte) bool {
+	return c < 0x80 && unicode.IsSpace(rune(c))
+}
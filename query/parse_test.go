@@ -0,0 +1,169 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	syntax := &query.Syntax{
+		Operators: map[string]string{
+			query.OpSite:   "site:",
+			query.OpInURL:  "inurl:",
+			query.OpInText: "intext:",
+			query.OpExt:    "ext:",
+		},
+		And: "+",
+		Or:  "|",
+		Not: "-",
+	}
+
+	t.Run("should parse operators, exclusions and phrases", func(t *testing.T) {
+		q, err := syntax.Parse(`site:example.com intext:"admin" -inurl:"login" "index of"`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.com"},
+			query.Operator{Name: query.OpInText, Value: "admin", Quoted: true},
+			query.Not{Node: query.Operator{Name: query.OpInURL, Value: "login", Quoted: true}},
+			query.Phrase{Text: "index of"},
+		}, q, "they should be equal")
+	})
+
+	t.Run("should parse operators and groups", func(t *testing.T) {
+		q, err := syntax.Parse(`(site:a.com | site:b.com) + intext:"x" OR -(php AND html)`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Group{Nodes: query.Query{
+				query.Operator{Name: query.OpSite, Value: "a.com"},
+				query.Or{},
+				query.Operator{Name: query.OpSite, Value: "b.com"},
+			}},
+			query.And{},
+			query.Operator{Name: query.OpInText, Value: "x", Quoted: true},
+			query.Or{},
+			query.Not{Node: query.Group{Nodes: query.Query{
+				query.Term{Text: "php"},
+				query.And{},
+				query.Term{Text: "html"},
+			}}},
+		}, q, "they should be equal")
+	})
+
+	t.Run("should keep parenthesized operator values", func(t *testing.T) {
+		q, err := syntax.Parse(`ext:(doc | pdf) site:`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Operator{Name: query.OpExt, Value: "(doc | pdf)"},
+			query.Operator{Name: query.OpSite},
+		}, q, "they should be equal")
+	})
+
+	t.Run("should keep unknown operators as terms", func(t *testing.T) {
+		q, err := syntax.Parse(`cache:"example.com" https://example.com - x`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Term{Text: `cache:"example.com"`},
+			query.Term{Text: "https://example.com"},
+			query.Term{Text: "-"},
+			query.Term{Text: "x"},
+		}, q, "they should be equal")
+	})
+
+	t.Run("should parse proximity operators, wildcards and ranges", func(t *testing.T) {
+		q, err := syntax.Parse(`"admin" AROUND(3) "password" * $100..$500 example..com`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Around{Left: query.Phrase{Text: "admin"}, Right: query.Phrase{Text: "password"}, Distance: 3},
+			query.Wildcard{},
+			query.Range{Low: "$100", High: "$500"},
+			query.Term{Text: "example..com"},
+		}, q, "they should be equal")
+	})
+
+	t.Run("should parse operator values containing a range", func(t *testing.T) {
+		q, err := syntax.Parse(`site:1..2 intext:"10..20" 1..2`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Operator{Name: query.OpSite, Value: "1..2"},
+			query.Operator{Name: query.OpInText, Value: "10..20", Quoted: true},
+			query.Range{Low: "1", High: "2"},
+		}, q, "they should be equal")
+	})
+
+	t.Run("should parse regular expressions", func(t *testing.T) {
+		regex := &query.Syntax{Not: "-", Regex: "/"}
+
+		q, err := regex.Parse(`/(a|b) c\/d/ -/"e"/ f/g`)
+
+		assert.Nil(err)
+		assert.Equal(query.Query{
+			query.Regex{Pattern: `(a|b) c\/d`},
+			query.Not{Node: query.Regex{Pattern: `"e"`}},
+			query.Term{Text: "f/g"},
+		}, q, "they should be equal")
+
+		for _, dork := range []string{`/(a|b`, `/abc\/`} {
+			_, err = regex.Parse(dork)
+
+			var syntaxErr *query.SyntaxError
+			if assert.True(errors.As(err, &syntaxErr), "it should be a syntax error") {
+				assert.Equal(0, syntaxErr.Offset, "they should be equal")
+			}
+		}
+	})
+
+	t.Run("should return positional syntax errors", func(t *testing.T) {
+		cases := []struct {
+			input  string
+			offset int
+		}{
+			{input: `site:example.com intext:"admin`, offset: 24},
+			{input: `"admin`, offset: 0},
+			{input: `(site:a.com | site:b.com`, offset: 24},
+			{input: `site:a.com)`, offset: 10},
+			{input: `ext:(doc | pdf`, offset: 4},
+			{input: `AROUND(3) "a"`, offset: 0},
+			{input: `"a" AROUND(3)`, offset: 13},
+			{input: `"a" AROUND(x) "b"`, offset: 4},
+			{input: `"a" AROUND(3) | "b"`, offset: 14},
+		}
+
+		for _, c := range cases {
+			_, err := syntax.Parse(c.input)
+
+			var syntaxErr *query.SyntaxError
+			if assert.True(errors.As(err, &syntaxErr), "it should be a syntax error") {
+				assert.Equal(c.offset, syntaxErr.Offset, c.input)
+			}
+		}
+	})
+
+	t.Run("should round trip", func(t *testing.T) {
+		inputs := []string{
+			`site:example.com intext:"admin" -inurl:"login"`,
+			`(site:a.com | site:b.com) + "index of" -(php | html) ext:(doc | pdf)`,
+			`  site:example.com   OR  intext:"a b"  `,
+		}
+
+		for _, input := range inputs {
+			q, err := syntax.Parse(input)
+			assert.Nil(err)
+
+			rendered := syntax.Render(q)
+			again, err := syntax.Parse(rendered)
+			assert.Nil(err)
+			assert.Equal(rendered, syntax.Render(again), input)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+/*
+Package query provides an engine-neutral representation of dork queries.
+Search engine builders store their tags as a tree of nodes, which can be inspected
+and rendered to the syntax of any supported search engine.
+*/
+package query
+
+// Names of the operators known by dorkgen. Each search engine maps them to its own syntax.
+const (
+	OpSite         = "site"
+	OpInURL        = "inurl"
+	OpURL          = "url"
+	OpFileType     = "filetype"
+	OpExt          = "ext"
+	OpCache        = "cache"
+	OpRelated      = "related"
+	OpInTitle      = "intitle"
+	OpInText       = "intext"
+	OpInAnchor     = "inanchor"
+	OpAllInText    = "allintext"
+	OpAllInTitle   = "allintitle"
+	OpAllInURL     = "allinurl"
+	OpBook         = "book"
+	OpIP           = "ip"
+	OpMaps         = "maps"
+	OpInfo         = "info"
+	OpRegion       = "region"
+	OpLanguage     = "language"
+	OpFeed         = "feed"
+	OpHasFeed      = "hasfeed"
+	OpContains     = "contains"
+	OpInStreamSet  = "instreamset"
+	OpBefore       = "before"
+	OpAfter        = "after"
+	OpAllInAnchor  = "allinanchor"
+	OpInPostAuthor = "inpostauthor"
+	OpSource       = "source"
+	OpDefine       = "define"
+	OpStocks       = "stocks"
+	OpWeather      = "weather"
+	OpLocation     = "location"
+	OpHost         = "host"
+	OpDomain       = "domain"
+	OpDate         = "date"
+	OpInPage       = "inpage"
+	OpRepo         = "repo"
+	OpOrg          = "org"
+	OpUser         = "user"
+	OpPath         = "path"
+	OpFilename     = "filename"
+	OpIn           = "in"
+	OpSize         = "size"
+	OpFork         = "fork"
+)
+
+// DateFormat is the layout of dates used as values of the before and after operators.
+const DateFormat = "2006-01-02"
+
+// Node is an element of a query tree.
+type Node interface {
+	node()
+}
+
+// Term is a keyword rendered without any kind of formatting.
+type Term struct {
+	Text string
+}
+
+// Phrase is an exact phrase rendered between double quotes.
+type Phrase struct {
+	Text string
+}
+
+// Operator is a search operator along with its value, such as site:example.com.
+type Operator struct {
+	Name   string
+	Value  string
+	Quoted bool
+}
+
+// And is the AND operator placed between two nodes.
+type And struct{}
+
+// Or is the OR operator placed between two nodes.
+type Or struct{}
+
+// Not excludes results matching its node.
+type Not struct {
+	Node Node
+}
+
+// Group isolates nodes between parentheses.
+type Group struct {
+	Nodes Query
+}
+
+// Around matches its nodes when they are separated by at most Distance words, such as "a" AROUND(3) "b".
+type Around struct {
+	Left     Node
+	Right    Node
+	Distance int
+}
+
+// Wildcard matches any word.
+type Wildcard struct{}
+
+// Range matches numbers between two bounds, such as $100..$500.
+type Range struct {
+	Low  string
+	High string
+}
+
+// Regex is a regular expression rendered between the delimiters of the syntax, such as /AKIA[0-9A-Z]{16}/.
+// Delimiters inside the pattern must be escaped with a backslash.
+type Regex struct {
+	Pattern string
+}
+
+func (Term) node()     {}
+func (Phrase) node()   {}
+func (Operator) node() {}
+func (And) node()      {}
+func (Or) node()       {}
+func (Not) node()      {}
+func (Group) node()    {}
+func (Around) node()   {}
+func (Wildcard) node() {}
+func (Range) node()    {}
+func (Regex) node()    {}
+
+// Query is an ordered list of nodes.
+type Query []Node
+
+// Copy returns a deep copy of the query, so it can be modified without affecting the original.
+func (q Query) Copy() Query {
+	if q == nil {
+		return nil
+	}
+
+	c := make(Query, len(q))
+	for i, n := range q {
+		c[i] = copyNode(n)
+	}
+
+	return c
+}
+
+func copyNode(n Node) Node {
+	switch v := n.(type) {
+	case Not:
+		return Not{Node: copyNode(v.Node)}
+	case Group:
+		return Group{Nodes: v.Nodes.Copy()}
+	case Around:
+		return Around{Left: copyNode(v.Left), Right: copyNode(v.Right), Distance: v.Distance}
+	default:
+		return n
+	}
+}
+
+// Walk traverses the query in depth-first order, calling fn for each node.
+// Children of a node are skipped if fn returns false.
+func Walk(q Query, fn func(Node) bool) {
+	for _, n := range q {
+		walk(n, fn)
+	}
+}
+
+func walk(n Node, fn func(Node) bool) {
+	if !fn(n) {
+		return
+	}
+
+	switch v := n.(type) {
+	case Not:
+		walk(v.Node, fn)
+	case Group:
+		Walk(v.Nodes, fn)
+	case Around:
+		walk(v.Left, fn)
+		walk(v.Right, fn)
+	}
+}
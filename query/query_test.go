@@ -0,0 +1,61 @@
+package query_test
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should copy a query deeply", func(t *testing.T) {
+		q := query.Query{
+			query.Group{Nodes: query.Query{query.Term{Text: "a"}}},
+		}
+
+		c := q.Copy()
+		c[0].(query.Group).Nodes[0] = query.Term{Text: "b"}
+
+		assert.Equal(query.Term{Text: "a"}, q[0].(query.Group).Nodes[0], "they should be equal")
+	})
+
+	t.Run("should walk nodes in depth-first order", func(t *testing.T) {
+		q := query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.com"},
+			query.Not{Node: query.Term{Text: "php"}},
+			query.Group{Nodes: query.Query{query.Phrase{Text: "a"}, query.Or{}, query.Phrase{Text: "b"}}},
+		}
+
+		var visited []query.Node
+		query.Walk(q, func(n query.Node) bool {
+			visited = append(visited, n)
+			return true
+		})
+
+		assert.Equal([]query.Node{
+			q[0],
+			q[1],
+			query.Term{Text: "php"},
+			q[2],
+			query.Phrase{Text: "a"},
+			query.Or{},
+			query.Phrase{Text: "b"},
+		}, visited, "they should be equal")
+	})
+
+	t.Run("should skip children when asked to", func(t *testing.T) {
+		q := query.Query{
+			query.Group{Nodes: query.Query{query.Term{Text: "a"}}},
+		}
+
+		count := 0
+		query.Walk(q, func(n query.Node) bool {
+			count++
+			return false
+		})
+
+		assert.Equal(1, count, "they should be equal")
+	})
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Renderer converts a query tree into the syntax of a search engine.
+type Renderer interface {
+	Render(q Query) string
+}
+
+// Syntax is a table-driven Renderer describing how a search engine writes operators.
+type Syntax struct {
+	// Operators maps operator names to their prefix, such as "site:".
+	// Operators missing from the table are rendered using their name followed by a colon.
+	Operators map[string]string
+	And       string
+	Or        string
+	Not       string
+	// Around is the proximity operator, rendered as Around(n). Defaults to "AROUND".
+	Around string
+	// Regex is the delimiter of regular expressions, such as "/".
+	// Regular expressions are not recognized if it is empty, and rendered between slashes.
+	Regex string
+	// Features lists the primitives other than operators the search engine understands.
+	Features Feature
+}
+
+// Feature is a query primitive that only some search engines understand.
+type Feature uint
+
+// Features
+const (
+	// Proximity is the Around node, such as "a" AROUND(3) "b"
+	Proximity Feature = 1 << iota
+	// Wildcards is the Wildcard node, matching any word
+	Wildcards
+	// Ranges is the Range node, such as 1..10
+	Ranges
+	// Regexes is the Regex node, such as /AKIA[0-9A-Z]{16}/
+	Regexes
+)
+
+// Render converts all nodes to a single request
+func (s *Syntax) Render(q Query) string {
+	parts := make([]string, 0, len(q))
+	for _, n := range q {
+		parts = append(parts, s.render(n))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Supports reports whether the operator is part of the syntax table.
+func (s *Syntax) Supports(name string) bool {
+	_, ok := s.Operators[name]
+	return ok
+}
+
+// Has reports whether the primitive is part of the syntax.
+func (s *Syntax) Has(f Feature) bool {
+	return s.Features&f == f
+}
+
+func (s *Syntax) around() string {
+	if s.Around == "" {
+		return "AROUND"
+	}
+	return s.Around
+}
+
+func (s *Syntax) regex() string {
+	if s.Regex == "" {
+		return "/"
+	}
+	return s.Regex
+}
+
+func (s *Syntax) render(n Node) string {
+	switch v := n.(type) {
+	case Term:
+		return v.Text
+	case Phrase:
+		return "\"" + v.Text + "\""
+	case Operator:
+		prefix, ok := s.Operators[v.Name]
+		if !ok {
+			prefix = v.Name + ":"
+		}
+		if v.Quoted {
+			return prefix + "\"" + v.Value + "\""
+		}
+		return prefix + v.Value
+	case And:
+		return s.And
+	case Or:
+		return s.Or
+	case Not:
+		return s.Not + s.render(v.Node)
+	case Group:
+		return "(" + s.Render(v.Nodes) + ")"
+	case Around:
+		return fmt.Sprintf("%s %s(%d) %s", s.render(v.Left), s.around(), v.Distance, s.render(v.Right))
+	case Wildcard:
+		return "*"
+	case Range:
+		return v.Low + ".." + v.High
+	case Regex:
+		return s.regex() + v.Pattern + s.regex()
+	}
+
+	return ""
+}
@@ -0,0 +1,64 @@
+package query_test
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestRender(t *testing.T) {
+	assert := assertion.New(t)
+
+	syntax := &query.Syntax{
+		Operators: map[string]string{
+			query.OpSite:   "site:",
+			query.OpInText: "inbody:",
+		},
+		And: "AND",
+		Or:  "OR",
+		Not: "-",
+	}
+
+	t.Run("should render every kind of node", func(t *testing.T) {
+		q := query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.com"},
+			query.And{},
+			query.Operator{Name: query.OpInText, Value: "admin", Quoted: true},
+			query.Not{Node: query.Term{Text: "php"}},
+			query.Group{Nodes: query.Query{query.Phrase{Text: "a"}, query.Or{}, query.Phrase{Text: "b"}}},
+		}
+
+		assert.Equal("site:example.com AND inbody:\"admin\" -php (\"a\" OR \"b\")", syntax.Render(q), "they should be equal")
+	})
+
+	t.Run("should render regular expressions between delimiters", func(t *testing.T) {
+		q := query.Query{query.Regex{Pattern: `a\/b`}}
+
+		assert.Equal(`/a\/b/`, syntax.Render(q), "they should be equal")
+		assert.Equal(`#a\/b#`, (&query.Syntax{Regex: "#"}).Render(q), "they should be equal")
+	})
+
+	t.Run("should fall back to the operator name", func(t *testing.T) {
+		q := query.Query{
+			query.Operator{Name: query.OpCache, Value: "example.com"},
+		}
+
+		assert.Equal("cache:example.com", syntax.Render(q), "they should be equal")
+		assert.False(syntax.Supports(query.OpCache), "it should not be supported")
+		assert.True(syntax.Supports(query.OpSite), "it should be supported")
+	})
+
+	t.Run("should report supported features", func(t *testing.T) {
+		proximity := &query.Syntax{Features: query.Proximity | query.Ranges}
+
+		assert.True(proximity.Has(query.Proximity), "it should be supported")
+		assert.True(proximity.Has(query.Ranges), "it should be supported")
+		assert.False(proximity.Has(query.Wildcards), "it should not be supported")
+		assert.False(syntax.Has(query.Proximity), "it should not be supported")
+	})
+
+	t.Run("should render an empty query", func(t *testing.T) {
+		assert.Equal("", syntax.Render(nil), "they should be equal")
+	})
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// EscapePolicy defines how double quotes embedded in a quoted value are handled.
+type EscapePolicy int
+
+const (
+	// StripQuotes removes embedded quotes from the value. This is the default policy.
+	StripQuotes EscapePolicy = iota
+	// EscapeQuotes prefixes embedded quotes with a backslash.
+	EscapeQuotes
+	// RejectQuotes keeps the value as is and reports an ErrUnbalancedQuote error.
+	RejectQuotes
+)
+
+// Sanitize prepares an operator value to be rendered. Control characters are removed
+// and whitespaces, including new lines, are collapsed into a single space.
+// Quoted values also have their embedded quotes handled according to the policy,
+// and their trailing backslashes removed so they can't escape the closing quote.
+// Unquoted values can't contain whitespaces, which would end the value and let the rest
+// be read as other tags, unless they are between parentheses such as "(doc | pdf)".
+func Sanitize(value string, quoted bool, policy EscapePolicy) (string, error) {
+	value = normalizeSpace(value)
+	if !quoted {
+		if hasUnquotedSpace(value) {
+			return value, fmt.Errorf("%w: %s", ErrUnquotedSpace, value)
+		}
+		return value, nil
+	}
+
+	value = strings.TrimRight(value, "\\")
+
+	var b strings.Builder
+	for i, r := range value {
+		if !isQuote(r) {
+			b.WriteRune(r)
+			continue
+		}
+
+		switch policy {
+		case EscapeQuotes:
+			if i == 0 || value[i-1] != '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte('"')
+		case RejectQuotes:
+			return value, fmt.Errorf("%w: %s", ErrUnbalancedQuote, value)
+		}
+	}
+
+	return b.String(), nil
+}
+
+func normalizeSpace(value string) string {
+	var b strings.Builder
+	space := false
+
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if unicode.IsControl(r) {
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// hasUnquotedSpace reports whether the value contains a whitespace outside of parentheses.
+func hasUnquotedSpace(value string) bool {
+	depth := 0
+	for _, r := range value {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case unicode.IsSpace(r) && depth == 0:
+			return true
+		}
+	}
+
+	return false
+}
+
+// isQuote reports whether the rune is a double quote, including typographic quotes
+// that search engines treat the same way.
+func isQuote(r rune) bool {
+	return r == '"' || r == '“' || r == '”' || r == '„'
+}
+
+// unescapedQuotes counts double quotes that are not preceded by a backslash.
+func unescapedQuotes(value string) int {
+	count := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] == '"' && (i == 0 || value[i-1] != '\\') {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,82 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestSanitize(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should normalize whitespaces and control characters", func(t *testing.T) {
+		cases := map[string]string{
+			"  admin\n\tpanel  ":      "admin panel",
+			"a\r\nb":                  "a b",
+			"a\x00b\x1bc":             "abc",
+			"mot de passe \u00a0 été": "mot de passe été",
+			"パスワード":                   "パスワード",
+		}
+
+		for input, expected := range cases {
+			result, err := query.Sanitize(input, true, query.StripQuotes)
+
+			assert.Nil(err)
+			assert.Equal(expected, result, input)
+		}
+	})
+
+	t.Run("should apply the escape policy to quoted values", func(t *testing.T) {
+		cases := []struct {
+			input    string
+			policy   query.EscapePolicy
+			expected string
+		}{
+			{input: `say "hi"`, policy: query.StripQuotes, expected: `say hi`},
+			{input: `say “hi”`, policy: query.StripQuotes, expected: `say hi`},
+			{input: `say "hi"`, policy: query.EscapeQuotes, expected: `say \"hi\"`},
+			{input: `say \"hi\"`, policy: query.EscapeQuotes, expected: `say \"hi\"`},
+			{input: `C:\dir\`, policy: query.EscapeQuotes, expected: `C:\dir`},
+			{input: `intext:"x"`, policy: query.StripQuotes, expected: `intext:x`},
+		}
+
+		for _, c := range cases {
+			result, err := query.Sanitize(c.input, true, c.policy)
+
+			assert.Nil(err)
+			assert.Equal(c.expected, result, c.input)
+		}
+	})
+
+	t.Run("should reject embedded quotes", func(t *testing.T) {
+		result, err := query.Sanitize("say\n\"hi\"", true, query.RejectQuotes)
+
+		assert.True(errors.Is(err, query.ErrUnbalancedQuote), "it should be an unbalanced quote error")
+		assert.Equal(`say "hi"`, result, "they should be equal")
+	})
+
+	t.Run("should reject whitespaces in unquoted values", func(t *testing.T) {
+		for _, input := range []string{"bbc intext:x", "en site:evil.com", "a\nb", "(doc | pdf) site:evil.com"} {
+			_, err := query.Sanitize(input, false, query.StripQuotes)
+
+			assert.True(errors.Is(err, query.ErrUnquotedSpace), input)
+		}
+	})
+
+	t.Run("should accept whitespaces between parentheses in unquoted values", func(t *testing.T) {
+		for _, input := range []string{"(doc | pdf)", "(url title):admin", "  example.com\n"} {
+			_, err := query.Sanitize(input, false, query.StripQuotes)
+
+			assert.Nil(err, input)
+		}
+	})
+
+	t.Run("should keep quotes in unquoted values", func(t *testing.T) {
+		result, err := query.Sanitize(`a"b`, false, query.RejectQuotes)
+
+		assert.Nil(err)
+		assert.Equal(`a"b`, result, "they should be equal")
+	})
+}
@@ -0,0 +1,90 @@
+package query
+
+import "fmt"
+
+// Names of the AND and OR connectives, used to describe them in operator tables.
+const (
+	OpAnd = "and"
+	OpOr  = "or"
+)
+
+// Status describes whether a search engine still honors an operator.
+type Status int
+
+const (
+	// Supported means the operator works as documented.
+	Supported Status = iota
+	// Degraded means the operator is still accepted, but results are unreliable or partial.
+	Degraded
+	// Deprecated means the operator was retired and is ignored or treated as a keyword.
+	Deprecated
+)
+
+func (s Status) String() string {
+	switch s {
+	case Supported:
+		return "supported"
+	case Degraded:
+		return "degraded"
+	case Deprecated:
+		return "deprecated"
+	}
+
+	return "unknown"
+}
+
+// OperatorInfo describes the status of an operator on a search engine.
+type OperatorInfo struct {
+	// Name is the name of the operator, such as OpCache or OpAnd
+	Name   string
+	Status Status
+	// Since is the date the operator got its current status, formatted as YYYY-MM, if known
+	Since string
+	// Note explains the status
+	Note string
+}
+
+// Warning is a diagnostic about a tag that is valid but may not behave as expected.
+type Warning struct {
+	// Index is the position of the offending tag in the request
+	Index    int
+	Operator OperatorInfo
+}
+
+func (w Warning) String() string {
+	msg := fmt.Sprintf("tag %d: %s is %s", w.Index, w.Operator.Name, w.Operator.Status)
+	if w.Operator.Since != "" {
+		msg += " since " + w.Operator.Since
+	}
+	if w.Operator.Note != "" {
+		msg += ": " + w.Operator.Note
+	}
+
+	return msg
+}
+
+// Warnings returns a warning for each operator of the query that is not fully supported according to the table,
+// which describes operators indexed by name. AND and OR operators are looked up as OpAnd and OpOr.
+func Warnings(q Query, table map[string]OperatorInfo) []Warning {
+	var warnings []Warning
+	for i, n := range q {
+		Walk(Query{n}, func(n Node) bool {
+			name := ""
+			switch v := n.(type) {
+			case Operator:
+				name = v.Name
+			case And:
+				name = OpAnd
+			case Or:
+				name = OpOr
+			}
+
+			if info, ok := table[name]; ok && info.Status != Supported {
+				warnings = append(warnings, Warning{Index: i, Operator: info})
+			}
+			return true
+		})
+	}
+
+	return warnings
+}
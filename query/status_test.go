@@ -0,0 +1,41 @@
+package query_test
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestWarnings(t *testing.T) {
+	assert := assertion.New(t)
+
+	table := map[string]query.OperatorInfo{
+		query.OpSite:  {Name: query.OpSite, Status: query.Supported},
+		query.OpCache: {Name: query.OpCache, Status: query.Deprecated, Since: "2024-02"},
+		query.OpOr:    {Name: query.OpOr, Status: query.Degraded, Note: "ignored"},
+	}
+
+	t.Run("should warn about operators that are not fully supported", func(t *testing.T) {
+		q := query.Query{
+			query.Operator{Name: query.OpSite, Value: "a.com"},
+			query.Or{},
+			query.Not{Node: query.Operator{Name: query.OpCache, Value: "a.com"}},
+			query.Operator{Name: query.OpInText, Value: "x"},
+		}
+
+		warnings := query.Warnings(q, table)
+
+		assert.Equal([]query.Warning{
+			{Index: 1, Operator: table[query.OpOr]},
+			{Index: 2, Operator: table[query.OpCache]},
+		}, warnings, "they should be equal")
+		assert.Equal("tag 1: or is degraded: ignored", warnings[0].String(), "they should be equal")
+		assert.Equal("tag 2: cache is deprecated since 2024-02", warnings[1].String(), "they should be equal")
+	})
+
+	t.Run("should format statuses", func(t *testing.T) {
+		assert.Equal("supported", query.Supported.String(), "they should be equal")
+		assert.Equal("unknown", query.Status(42).String(), "they should be equal")
+	})
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ParseBaseURL parses the URL requests of a search engine are sent to, which must be absolute.
+func ParseBaseURL(rawURL string) (*url.URL, error) {
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBaseURL, err)
+	}
+	if !baseURL.IsAbs() || baseURL.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBaseURL, rawURL)
+	}
+
+	return baseURL, nil
+}
+
+// BuildURL encodes the URL parameters of a request into the base URL, or into the default URL if the base URL is empty.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func BuildURL(rawURL, defaultURL string, params url.Values) (string, error) {
+	if rawURL == "" {
+		rawURL = defaultURL
+	}
+
+	baseURL, err := ParseBaseURL(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	values := baseURL.Query()
+	for k, v := range params {
+		values[k] = v
+	}
+	baseURL.RawQuery = values.Encode()
+
+	return baseURL.String(), nil
+}
@@ -0,0 +1,38 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestBuildURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	params := url.Values{"q": []string{"site:example.com"}}
+
+	t.Run("should use the default URL", func(t *testing.T) {
+		result, err := query.BuildURL("", "https://example.com/search", params)
+
+		assert.Nil(err)
+		assert.Equal("https://example.com/search?q=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should keep query parameters of the base URL", func(t *testing.T) {
+		result, err := query.BuildURL("http://localhost:8080/s?key=abc&q=old", "https://example.com/search", params)
+
+		assert.Nil(err)
+		assert.Equal("http://localhost:8080/s?key=abc&q=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should reject invalid base URLs", func(t *testing.T) {
+		for _, rawURL := range []string{"/search", "example.com", "http://[::1", "https://"} {
+			_, err := query.BuildURL(rawURL, "https://example.com/search", params)
+
+			assert.True(errors.Is(err, query.ErrInvalidBaseURL), rawURL)
+		}
+	})
+}
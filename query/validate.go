@@ -0,0 +1,394 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Errors returned when validating a query.
+var (
+	// ErrDanglingOperator is returned for an AND or OR operator without an operand on both sides.
+	ErrDanglingOperator = errors.New("dangling operator")
+	// ErrEmptyGroup is returned for a group or an exclusion without any tag.
+	ErrEmptyGroup = errors.New("empty group")
+	// ErrEmptyValue is returned for an operator without value.
+	ErrEmptyValue = errors.New("empty value")
+	// ErrInvalidDomain is returned for a site that is not a valid domain name.
+	ErrInvalidDomain = errors.New("invalid domain")
+	// ErrInvalidDate is returned for a date that is not formatted as YYYY-MM-DD, YYYY-MM or YYYY.
+	ErrInvalidDate = errors.New("invalid date")
+	// ErrInvalidDateRange is returned for a date range ending before it starts.
+	ErrInvalidDateRange = errors.New("invalid date range")
+	// ErrInvalidDistance is returned for an AROUND operator with a negative distance.
+	ErrInvalidDistance = errors.New("invalid distance")
+	// ErrInvalidRange is returned for a numeric range without both bounds, or with its lower bound above its upper bound.
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrInvalidTerm is returned for a term that is not a single word, or an empty or unterminated regular expression.
+	ErrInvalidTerm = errors.New("invalid term")
+	// ErrInvalidExtension is returned for a file extension or type that is not valid, such as ".pdf".
+	ErrInvalidExtension = errors.New("invalid file extension")
+	// ErrInvalidExclusion is returned for tags that can't be excluded, such as groups on engines that don't support it.
+	ErrInvalidExclusion = errors.New("invalid exclusion")
+	// ErrInvalidBaseURL is returned for a search engine base URL that is not an absolute URL.
+	ErrInvalidBaseURL = errors.New("invalid base URL")
+	// ErrInvalidParameter is returned for a URL parameter with a value that is not allowed.
+	ErrInvalidParameter = errors.New("invalid parameter")
+	// ErrNonCombinable is returned for an operator that can't be combined with other operators, such as allintitle on Google.
+	ErrNonCombinable = errors.New("non-combinable operator")
+	// ErrDeprecatedOperator is returned in strict mode for an operator the search engine no longer honors.
+	ErrDeprecatedOperator = errors.New("deprecated operator")
+	// ErrInvalidTag is returned when decoding a tag of unknown type, or missing a required field.
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrEngineMismatch is returned when decoding a dork saved for another search engine.
+	ErrEngineMismatch = errors.New("search engine mismatch")
+	// ErrInvalidRepository is returned for a repository that is not formatted as owner/name.
+	ErrInvalidRepository = errors.New("invalid repository")
+	// ErrUnbalancedQuote is returned for a value containing quotes that would break the request.
+	ErrUnbalancedQuote = errors.New("unbalanced quote")
+	// ErrUnquotedSpace is returned for an unquoted value containing whitespaces, which would split it into several tags.
+	ErrUnquotedSpace = errors.New("whitespace in unquoted value")
+)
+
+// ValidationError describes a tag that makes the request invalid.
+type ValidationError struct {
+	// Index is the position of the offending tag in the request
+	Index int
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("tag %d: %s", e.Index, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validators checks the value of operators that expect a specific format.
+var validators = map[string]func(value string) error{
+	OpSite:     validateDomain,
+	OpExt:      validateExtension,
+	OpFileType: validateExtension,
+	OpBefore:   validateDate,
+	OpAfter:    validateDate,
+	OpRepo:     validateRepository,
+	OpSize:     validateSize,
+}
+
+// dateFormats lists the layouts accepted as values of the before and after operators.
+var dateFormats = []string{DateFormat, "2006-01", "2006"}
+
+// Validate checks the query is well-formed and returns a *ValidationError for the first invalid tag.
+func Validate(q Query) error {
+	for i := range q {
+		if err := validateAt(q, i); err != nil {
+			return &ValidationError{Index: i, Err: err}
+		}
+	}
+
+	return validateDateRange(q)
+}
+
+// validateDateRange checks the after operator doesn't come after the before operator.
+func validateDateRange(q Query) error {
+	var after, before *time.Time
+	for i, n := range q {
+		op, ok := n.(Operator)
+		if !ok || (op.Name != OpAfter && op.Name != OpBefore) {
+			continue
+		}
+
+		date, err := ParseDate(op.Value)
+		if err != nil {
+			continue
+		}
+		if op.Name == OpAfter && after == nil {
+			after = &date
+		} else if op.Name == OpBefore && before == nil {
+			before = &date
+		} else {
+			continue
+		}
+
+		if after != nil && before != nil && before.Before(*after) {
+			return &ValidationError{Index: i, Err: ErrInvalidDateRange}
+		}
+	}
+
+	return nil
+}
+
+// ValidateStandalone checks the given operators, when present, are the only operator of the query,
+// and returns a *ValidationError for the first tag breaking the rule. Keywords remain allowed.
+func ValidateStandalone(q Query, operators ...string) error {
+	standalone := map[string]bool{}
+	for _, name := range operators {
+		standalone[name] = true
+	}
+
+	count := 0
+	found := ""
+	for i, n := range q {
+		Walk(Query{n}, func(n Node) bool {
+			if op, ok := n.(Operator); ok {
+				count++
+				if found == "" && standalone[op.Name] {
+					found = op.Name
+				}
+			}
+			return true
+		})
+
+		if found != "" && count > 1 {
+			return &ValidationError{Index: i, Err: fmt.Errorf("%w: %s", ErrNonCombinable, found)}
+		}
+	}
+
+	return nil
+}
+
+// ParseDate parses the value of a before or after operator.
+func ParseDate(value string) (time.Time, error) {
+	for _, layout := range dateFormats {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, value)
+}
+
+// ValidateNext checks a node about to be appended to the query.
+// Unlike Validate, it can't detect AND and OR operators missing their right-hand operand.
+func ValidateNext(q Query, n Node) error {
+	if isConnective(n) && (len(q) == 0 || isConnective(q[len(q)-1])) {
+		return ErrDanglingOperator
+	}
+
+	return validateNode(n)
+}
+
+func validateNode(n Node) error {
+	switch v := n.(type) {
+	case Term:
+		if strings.Count(v.Text, "\"")%2 != 0 {
+			return fmt.Errorf("%w: %s", ErrUnbalancedQuote, v.Text)
+		}
+	case Phrase:
+		if unescapedQuotes(v.Text) > 0 {
+			return fmt.Errorf("%w: %s", ErrUnbalancedQuote, v.Text)
+		}
+	case Operator:
+		return validateOperator(v)
+	case Not:
+		if v.Node == nil {
+			return ErrEmptyGroup
+		}
+		if isConnective(v.Node) {
+			return ErrDanglingOperator
+		}
+		return validateNode(v.Node)
+	case Group:
+		if len(v.Nodes) == 0 {
+			return ErrEmptyGroup
+		}
+		if err := Validate(v.Nodes); err != nil {
+			return err
+		}
+	case Around:
+		return validateAround(v)
+	case Range:
+		return validateRange(v)
+	case Regex:
+		return validateRegex(v)
+	}
+
+	return nil
+}
+
+// validateRegex rejects empty patterns, and patterns ending with a lone backslash which would escape the closing delimiter.
+func validateRegex(r Regex) error {
+	trailing := len(r.Pattern) - len(strings.TrimRight(r.Pattern, "\\"))
+	if r.Pattern == "" || trailing%2 != 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidTerm, r.Pattern)
+	}
+
+	return nil
+}
+
+func validateAround(a Around) error {
+	if a.Distance < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidDistance, a.Distance)
+	}
+
+	for _, n := range []Node{a.Left, a.Right} {
+		if n == nil || isConnective(n) {
+			return ErrDanglingOperator
+		}
+		if err := validateNode(n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRange accepts bounds such as "100" or "$100", with a lower bound that doesn't exceed the upper bound.
+func validateRange(r Range) error {
+	low, lowErr := parseBound(r.Low)
+	high, highErr := parseBound(r.High)
+
+	if lowErr != nil || highErr != nil || low > high {
+		return fmt.Errorf("%w: %s..%s", ErrInvalidRange, r.Low, r.High)
+	}
+
+	return nil
+}
+
+// parseBound parses the number of a range bound, ignoring units such as currency symbols.
+func parseBound(bound string) (float64, error) {
+	number := strings.TrimFunc(bound, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+
+	if strings.IndexFunc(bound, unicode.IsSpace) >= 0 {
+		return 0, ErrInvalidRange
+	}
+
+	return strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+}
+
+// validateAt checks the node at the given index, using its siblings to validate AND and OR operators.
+func validateAt(q Query, i int) error {
+	switch q[i].(type) {
+	case And, Or:
+		if i == 0 || i == len(q)-1 || isConnective(q[i-1]) {
+			return ErrDanglingOperator
+		}
+		return nil
+	}
+
+	return validateNode(q[i])
+}
+
+func validateOperator(op Operator) error {
+	if op.Value == "" {
+		return fmt.Errorf("%w: %s", ErrEmptyValue, op.Name)
+	}
+
+	if op.Quoted && (unescapedQuotes(op.Value) > 0 || strings.HasSuffix(op.Value, "\\")) {
+		return fmt.Errorf("%w: %s", ErrUnbalancedQuote, op.Value)
+	}
+	if !op.Quoted && strings.Count(op.Value, "\"")%2 != 0 {
+		return fmt.Errorf("%w: %s", ErrUnbalancedQuote, op.Value)
+	}
+	if validate, ok := validators[op.Name]; ok {
+		if err := validate(op.Value); err != nil {
+			return err
+		}
+	}
+
+	if !op.Quoted && hasUnquotedSpace(op.Value) {
+		return fmt.Errorf("%w: %s", ErrUnquotedSpace, op.Value)
+	}
+
+	return nil
+}
+
+// validateDomain accepts domain names with wildcards, such as "*.example.com" or "example.*",
+// top-level domains such as ".gov" and an optional path.
+func validateDomain(value string) error {
+	host := value
+	if i := strings.IndexByte(value, '/'); i >= 0 {
+		host = value[:i]
+	}
+	host = strings.TrimPrefix(host, ".")
+
+	if host == "" || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidDomain, value)
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("%w: %q", ErrInvalidDomain, value)
+		}
+		for _, r := range label {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '*' {
+				return fmt.Errorf("%w: %q", ErrInvalidDomain, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRepository accepts repositories formatted as owner/name, such as "sundowndev/dorkgen".
+func validateRepository(value string) error {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidRepository, value)
+	}
+
+	return nil
+}
+
+// validateSize accepts sizes in bytes such as "1000", ">1000", "<=1000" or "1000..2000".
+func validateSize(value string) error {
+	if i := strings.Index(value, ".."); i >= 0 {
+		low, lowErr := strconv.Atoi(value[:i])
+		high, highErr := strconv.Atoi(value[i+2:])
+		if lowErr != nil || highErr != nil || low < 0 || low > high {
+			return fmt.Errorf("%w: %q", ErrInvalidRange, value)
+		}
+		return nil
+	}
+
+	size := value
+	for _, prefix := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(size, prefix) {
+			size = size[len(prefix):]
+			break
+		}
+	}
+
+	if n, err := strconv.Atoi(size); err != nil || n < 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidRange, value)
+	}
+
+	return nil
+}
+
+func validateDate(value string) error {
+	_, err := ParseDate(value)
+	return err
+}
+
+// validateExtension accepts extensions such as "pdf" or groups of extensions such as "(doc | pdf)".
+func validateExtension(value string) error {
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		return nil
+	}
+
+	if strings.HasPrefix(value, ".") || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidExtension, value)
+	}
+
+	return nil
+}
+
+func errInvalidExclusion(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidExclusion, reason)
+}
+
+func isConnective(n Node) bool {
+	switch n.(type) {
+	case And, Or:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestValidate(t *testing.T) {
+	assert := assertion.New(t)
+
+	site := func(value string) query.Node {
+		return query.Operator{Name: query.OpSite, Value: value}
+	}
+
+	t.Run("should accept valid queries", func(t *testing.T) {
+		queries := []query.Query{
+			nil,
+			{site("example.com"), query.Or{}, site("*.example.org")},
+			{site("example.*"), site(".gov"), site("example.com/path"), site("exämple.fr")},
+			{query.Operator{Name: query.OpRepo, Value: "sundowndev/dorkgen"}, query.Operator{Name: query.OpSize, Value: ">=1000"}, query.Operator{Name: query.OpSize, Value: "10..20"}},
+			{query.Operator{Name: query.OpExt, Value: "(doc | pdf)"}, query.Operator{Name: query.OpFileType, Value: "pdf", Quoted: true}},
+			{query.Not{Node: query.Group{Nodes: query.Query{query.Term{Text: `"a"`}}}}},
+			{query.Around{Left: query.Phrase{Text: "a"}, Right: query.Term{Text: "b"}}, query.Wildcard{}, query.Range{Low: "$1,000", High: "$2,000"}},
+			{query.Regex{Pattern: `key="`}, query.Regex{Pattern: `a\\`}},
+		}
+
+		for _, q := range queries {
+			assert.Nil(query.Validate(q))
+		}
+	})
+
+	t.Run("should reject invalid queries", func(t *testing.T) {
+		cases := []struct {
+			query query.Query
+			err   error
+			index int
+		}{
+			{query: query.Query{query.Or{}, site("a.com")}, err: query.ErrDanglingOperator, index: 0},
+			{query: query.Query{site("a.com"), query.Or{}}, err: query.ErrDanglingOperator, index: 1},
+			{query: query.Query{site("a.com"), query.And{}, query.And{}, site("b.com")}, err: query.ErrDanglingOperator, index: 2},
+			{query: query.Query{query.Group{Nodes: query.Query{site("a.com"), query.Or{}}}}, err: query.ErrDanglingOperator, index: 0},
+			{query: query.Query{query.Not{Node: query.Or{}}}, err: query.ErrDanglingOperator, index: 0},
+			{query: query.Query{site("a.com"), query.Group{}}, err: query.ErrEmptyGroup, index: 1},
+			{query: query.Query{site("has spaces")}, err: query.ErrInvalidDomain, index: 0},
+			{query: query.Query{site("a..com")}, err: query.ErrInvalidDomain, index: 0},
+			{query: query.Query{site("-a.com")}, err: query.ErrInvalidDomain, index: 0},
+			{query: query.Query{site("a.com"), site("")}, err: query.ErrEmptyValue, index: 1},
+			{query: query.Query{query.Operator{Name: query.OpSource, Value: "bbc intext:x"}}, err: query.ErrUnquotedSpace, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpExt, Value: ".pdf"}}, err: query.ErrInvalidExtension, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpInText, Value: `say "hi"`, Quoted: true}}, err: query.ErrUnbalancedQuote, index: 0},
+			{query: query.Query{query.Term{Text: `"admin`}}, err: query.ErrUnbalancedQuote, index: 0},
+			{query: query.Query{query.Phrase{Text: `a"b`}}, err: query.ErrUnbalancedQuote, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpBefore, Value: "01/01/2020"}}, err: query.ErrInvalidDate, index: 0},
+			{query: query.Query{query.Around{Left: query.Phrase{Text: "a"}, Right: query.Phrase{Text: "b"}, Distance: -1}}, err: query.ErrInvalidDistance, index: 0},
+			{query: query.Query{query.Around{Left: query.Phrase{Text: "a"}, Right: query.Or{}}}, err: query.ErrDanglingOperator, index: 0},
+			{query: query.Query{query.Range{Low: "10", High: "1"}}, err: query.ErrInvalidRange, index: 0},
+			{query: query.Query{query.Range{Low: "a", High: "b"}}, err: query.ErrInvalidRange, index: 0},
+			{query: query.Query{query.Regex{}}, err: query.ErrInvalidTerm, index: 0},
+			{query: query.Query{site("a.com"), query.Regex{Pattern: `abc\`}}, err: query.ErrInvalidTerm, index: 1},
+			{query: query.Query{query.Operator{Name: query.OpRepo, Value: "dorkgen"}}, err: query.ErrInvalidRepository, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpRepo, Value: "a/b/c"}}, err: query.ErrInvalidRepository, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpSize, Value: "20..10"}}, err: query.ErrInvalidRange, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpSize, Value: "<>1"}}, err: query.ErrInvalidRange, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpSize, Value: "1kb"}}, err: query.ErrInvalidRange, index: 0},
+			{query: query.Query{query.Operator{Name: query.OpAfter, Value: "2020-02"}, query.Operator{Name: query.OpBefore, Value: "2020-01-31"}}, err: query.ErrInvalidDateRange, index: 1},
+		}
+
+		for _, c := range cases {
+			err := query.Validate(c.query)
+
+			var validationErr *query.ValidationError
+			if assert.True(errors.As(err, &validationErr), "it should be a validation error") {
+				assert.True(errors.Is(err, c.err), err.Error())
+				assert.Equal(c.index, validationErr.Index, err.Error())
+			}
+		}
+	})
+
+	t.Run("should validate the next node", func(t *testing.T) {
+		q := query.Query{site("a.com")}
+
+		assert.Nil(query.ValidateNext(q, query.Or{}))
+		assert.Equal(query.ErrDanglingOperator, query.ValidateNext(nil, query.Or{}))
+		assert.Equal(query.ErrDanglingOperator, query.ValidateNext(query.Query{site("a.com"), query.And{}}, query.And{}))
+		assert.True(errors.Is(query.ValidateNext(q, site("a b")), query.ErrInvalidDomain))
+	})
+
+	t.Run("should validate standalone operators", func(t *testing.T) {
+		allintitle := query.Operator{Name: query.OpAllInTitle, Value: "admin", Quoted: true}
+
+		assert.Nil(query.ValidateStandalone(query.Query{allintitle, query.Term{Text: "login"}}, query.OpAllInTitle))
+		assert.Nil(query.ValidateStandalone(query.Query{site("a.com"), site("b.com")}, query.OpAllInTitle))
+		assert.EqualError(
+			query.ValidateStandalone(query.Query{query.Term{Text: "login"}, site("a.com"), query.Group{Nodes: query.Query{allintitle}}}, query.OpAllInTitle),
+			"tag 2: non-combinable operator: allintitle",
+		)
+	})
+
+	t.Run("should format validation errors", func(t *testing.T) {
+		err := query.Validate(query.Query{site("a.com"), site("has spaces")})
+
+		assert.EqualError(err, "tag 1: invalid domain: \"has spaces\"")
+	})
+}
@@ -0,0 +1,173 @@
+package searxng
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// TimeRange restricts results to a recent period, used as the "time_range" URL parameter.
+type TimeRange string
+
+// Time ranges
+const (
+	PastDay   TimeRange = "day"
+	PastWeek  TimeRange = "week"
+	PastMonth TimeRange = "month"
+	PastYear  TimeRange = "year"
+)
+
+// SafeSearch is the safe search level, used as the "safesearch" URL parameter.
+type SafeSearch int
+
+// Safe search levels
+const (
+	SafeSearchOff SafeSearch = iota
+	SafeSearchModerate
+	SafeSearchStrict
+)
+
+// Format is the format of the results, used as the "format" URL parameter.
+// Formats other than HTML must be enabled in the settings of the instance.
+type Format string
+
+// Result formats
+const (
+	JSON Format = "json"
+	CSV  Format = "csv"
+	RSS  Format = "rss"
+)
+
+var languageCode = regexp.MustCompile(`^(all|auto|[a-z]{2,3}(-[A-Za-z]{2,4})?)$`)
+
+func (e *SearXNG) set(param string, value string) *SearXNG {
+	if e.params == nil {
+		e.params = url.Values{}
+	}
+	e.params.Set(param, value)
+	return e
+}
+
+func (e *SearXNG) invalid(param string, value interface{}) *SearXNG {
+	if e.err == nil {
+		e.err = fmt.Errorf("%w: %s=%v", query.ErrInvalidParameter, param, value)
+	}
+	return e
+}
+
+// bang adds a !bang prefix to the request.
+func (e *SearXNG) bang(name string) *SearXNG {
+	if !isBang(name) {
+		return e.invalid("bang", name)
+	}
+
+	e.bangs = append(e.bangs, name)
+	return e
+}
+
+// EngineBang restricts the search to an engine using its !bang shortcut, such as "go" for Google or "wp" for Wikipedia.
+// Shortcuts are listed in the preferences of the instance.
+func (e *SearXNG) EngineBang(shortcut string) *SearXNG {
+	return e.bang(shortcut)
+}
+
+// Bangs returns a copy of the engine and category !bangs of the request, without their prefix.
+func (e *SearXNG) Bangs() []string {
+	return append([]string(nil), e.bangs...)
+}
+
+// CategoryBang restricts the search to a category using its !bang, such as "images" or "social media".
+func (e *SearXNG) CategoryBang(category string) *SearXNG {
+	return e.bang(strings.ReplaceAll(category, " ", "_"))
+}
+
+// Categories restricts the search to the given categories, such as "general" or "it" (categories parameter).
+func (e *SearXNG) Categories(categories ...string) *SearXNG {
+	if !isList(categories) {
+		return e.invalid("categories", categories)
+	}
+	return e.set("categories", strings.Join(categories, ","))
+}
+
+// Engines restricts the search to the given engines, such as "google" or "duckduckgo" (engines parameter).
+func (e *SearXNG) Engines(engines ...string) *SearXNG {
+	if !isList(engines) {
+		return e.invalid("engines", engines)
+	}
+	return e.set("engines", strings.Join(engines, ","))
+}
+
+// Language restricts results to a language, such as "fr", "en-US", or "all" (language parameter).
+func (e *SearXNG) Language(lang string) *SearXNG {
+	if !languageCode.MatchString(lang) {
+		return e.invalid("language", lang)
+	}
+	return e.set("language", lang)
+}
+
+// TimeRange restricts results to a recent period (time_range parameter).
+// Engines that don't support time ranges are skipped by SearXNG.
+func (e *SearXNG) TimeRange(r TimeRange) *SearXNG {
+	switch r {
+	case PastDay, PastWeek, PastMonth, PastYear:
+		return e.set("time_range", string(r))
+	}
+	return e.invalid("time_range", r)
+}
+
+// SafeSearch sets the safe search level (safesearch parameter).
+func (e *SearXNG) SafeSearch(level SafeSearch) *SearXNG {
+	switch level {
+	case SafeSearchOff, SafeSearchModerate, SafeSearchStrict:
+		return e.set("safesearch", strconv.Itoa(int(level)))
+	}
+	return e.invalid("safesearch", level)
+}
+
+// Page sets the result page to show, starting at 1 (pageno parameter).
+func (e *SearXNG) Page(page int) *SearXNG {
+	if page < 1 {
+		return e.invalid("pageno", page)
+	}
+	return e.set("pageno", strconv.Itoa(page))
+}
+
+// Format sets the format of the results, such as JSON to use the instance as an API (format parameter).
+func (e *SearXNG) Format(f Format) *SearXNG {
+	switch f {
+	case JSON, CSV, RSS:
+		return e.set("format", string(f))
+	}
+	return e.invalid("format", f)
+}
+
+func isBang(bang string) bool {
+	if bang == "" {
+		return false
+	}
+	for _, r := range bang {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isList reports whether the names can be joined as a comma-separated list.
+func isList(names []string) bool {
+	if len(names) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" || strings.Contains(name, ",") {
+			return false
+		}
+	}
+
+	return true
+}
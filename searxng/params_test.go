@@ -0,0 +1,44 @@
+package searxng_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/searxng"
+)
+
+func TestParams(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should set URL parameters", func(t *testing.T) {
+		dork = searxng.New(instance).
+			Site("example.com").
+			Categories("general", "social media").
+			Engines("google", "duckduckgo").
+			Language("en-US").
+			TimeRange(searxng.PastMonth).
+			SafeSearch(searxng.SafeSearchStrict).
+			Page(2).
+			Format(searxng.JSON)
+
+		assert.Nil(dork.Err())
+		assert.Equal("general,social media", dork.QueryValues().Get("categories"), "they should be equal")
+		assert.Equal("https://searx.example.org/search?categories=general%2Csocial+media&engines=google%2Cduckduckgo&format=json&language=en-US&pageno=2&q=site%3Aexample.com&safesearch=2&time_range=month", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		dork = searxng.New(instance).Page(0)
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidParameter), "it should be an invalid parameter error")
+		assert.EqualError(dork.Err(), "invalid parameter: pageno=0")
+
+		assert.EqualError(searxng.New(instance).Categories().Err(), "invalid parameter: categories=[]")
+		assert.EqualError(searxng.New(instance).Engines("google,bing").Err(), "invalid parameter: engines=[google,bing]")
+		assert.EqualError(searxng.New(instance).Language("english").Err(), "invalid parameter: language=english")
+		assert.EqualError(searxng.New(instance).TimeRange("hour").Err(), "invalid parameter: time_range=hour")
+		assert.EqualError(searxng.New(instance).SafeSearch(3).Err(), "invalid parameter: safesearch=3")
+		assert.EqualError(searxng.New(instance).Format("xml").Err(), "invalid parameter: format=xml")
+	})
+}
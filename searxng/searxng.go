@@ -0,0 +1,294 @@
+package searxng
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "searxng"
+
+const (
+	searchPath = "search"
+	bangPrefix = "!"
+)
+
+// operators lists the Google operators understood by most of the engines SearXNG forwards requests to.
+var operators = []string{
+	query.OpSite,
+	query.OpInURL,
+	query.OpFileType,
+	query.OpExt,
+	query.OpInTitle,
+	query.OpInText,
+}
+
+var syntax = newSyntax()
+
+// newSyntax returns the Google Search syntax restricted to the operators of SearXNG,
+// without primitives such as proximity searches that only Google understands.
+func newSyntax() *query.Syntax {
+	s := googlesearch.Syntax()
+
+	supported := make(map[string]string, len(operators))
+	for _, name := range operators {
+		supported[name] = s.Operators[name]
+	}
+	s.Operators = supported
+	s.Features = 0
+
+	return s
+}
+
+// SearXNG is the SearXNG metasearch implementation for Dorkgen.
+// SearXNG is self-hosted, so each instance targets the SearXNG instance given to New.
+type SearXNG struct {
+	nodes    query.Query
+	params   url.Values
+	err      error
+	escape   query.EscapePolicy
+	instance string
+	// bangs holds the engine and category !bangs prefixing the request
+	bangs []string
+}
+
+// Option configures an instance of SearXNG
+type Option func(*SearXNG)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *SearXNG) {
+		e.escape = policy
+	}
+}
+
+// New creates a new instance of SearXNG sending requests to the given SearXNG instance,
+// such as "https://searx.example.org" or "http://localhost:8888".
+func New(instance string, opts ...Option) *SearXNG {
+	e := &SearXNG{instance: instance}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of SearXNG from a query tree
+func FromQuery(instance string, q query.Query) *SearXNG {
+	return &SearXNG{nodes: q.Copy(), instance: instance}
+}
+
+// Parse converts a dork string into a new instance of SearXNG.
+// Leading !bangs are recognized and set as the bangs of the request, other words starting with ! are kept as terms.
+func Parse(instance string, dork string) (*SearXNG, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &SearXNG{nodes: q, instance: instance}
+	for len(e.nodes) > 0 {
+		t, ok := e.nodes[0].(query.Term)
+		if !ok || !strings.HasPrefix(t.Text, bangPrefix) || !isBang(t.Text[1:]) {
+			break
+		}
+		e.nodes = e.nodes[1:]
+		e.bangs = append(e.bangs, t.Text[1:])
+	}
+
+	return e, nil
+}
+
+// Render converts a query tree to SearXNG syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in SearXNG
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in SearXNG
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *SearXNG) add(n query.Node) *SearXNG {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *SearXNG) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *SearXNG) operator(name string, value string, quotes bool) *SearXNG {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *SearXNG) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree, without the bangs
+func (e *SearXNG) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *SearXNG) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+func (e *SearXNG) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return query.Validate(e.nodes)
+}
+
+// String converts all tags to a single request, prefixed by the !bangs if any
+func (e *SearXNG) String() string {
+	if len(e.bangs) == 0 {
+		return syntax.Render(e.nodes)
+	}
+
+	return strings.TrimSpace(bangPrefix + strings.Join(e.bangs, " "+bangPrefix) + " " + syntax.Render(e.nodes))
+}
+
+// QueryValues returns search request as URL values, including URL parameters
+func (e *SearXNG) QueryValues() url.Values {
+	params := url.Values{}
+	for k, v := range e.params {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("q", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded URL of the SearXNG instance.
+// It returns an empty string if the instance URL is invalid, use URLE to get the error.
+func (e *SearXNG) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded URL of the SearXNG instance, or returns an error if the instance URL is invalid.
+// Requests are sent to the search endpoint of the instance, which may be served under a path such as "/searx/".
+func (e *SearXNG) URLE() (string, error) {
+	instance, err := query.ParseBaseURL(e.instance)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasSuffix(instance.Path, "/") {
+		instance.Path += "/"
+	}
+	baseURL := instance.ResolveReference(&url.URL{Path: searchPath})
+
+	values := instance.Query()
+	for k, v := range e.QueryValues() {
+		values[k] = v
+	}
+	baseURL.RawQuery = values.Encode()
+
+	return baseURL.String(), nil
+}
+
+// Site specifically searches that particular site and lists all the results for that site.
+func (e *SearXNG) Site(site string) *SearXNG {
+	return e.operator(query.OpSite, site, false)
+}
+
+// Or puts an OR operator in the request
+func (e *SearXNG) Or() *SearXNG {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request
+func (e *SearXNG) And() *SearXNG {
+	return e.add(query.And{})
+}
+
+// InText searches for the occurrences of keywords all at once or one at a time.
+func (e *SearXNG) InText(text string) *SearXNG {
+	return e.operator(query.OpInText, text, true)
+}
+
+// InURL searches for a URL matching one of the keywords.
+func (e *SearXNG) InURL(url string) *SearXNG {
+	return e.operator(query.OpInURL, url, true)
+}
+
+// FileType searches for a particular filetype mentioned in the query.
+func (e *SearXNG) FileType(filetype string) *SearXNG {
+	return e.operator(query.OpFileType, filetype, true)
+}
+
+// Ext searches for a particular file extension mentioned in the query.
+func (e *SearXNG) Ext(ext string) *SearXNG {
+	return e.operator(query.OpExt, ext, false)
+}
+
+// InTitle searches for occurrences of keywords in title all or one.
+func (e *SearXNG) InTitle(value string) *SearXNG {
+	return e.operator(query.OpInTitle, value, true)
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Groups can't be excluded as a whole, since not every engine queried by SearXNG supports it.
+func (e *SearXNG) Exclude(tags *SearXNG) *SearXNG {
+	nodes, err := query.Exclude(tags.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *SearXNG) Not(tag *SearXNG) *SearXNG {
+	n, err := query.Negate(tag.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *SearXNG) Group(tags *SearXNG) *SearXNG {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *SearXNG) Plain(value string) *SearXNG {
+	return e.add(query.Term{Text: value})
+}
@@ -0,0 +1,203 @@
+package searxng_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/searxng"
+)
+
+const instance = "https://searx.example.org"
+
+var dork *searxng.SearXNG
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = searxng.New(instance)
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal("https://searx.example.org/search?q=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = searxng.New(instance)
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal("site:example.com", result, "they should be equal")
+	})
+
+	t.Run("should handle operators correctly", func(t *testing.T) {
+		dork = searxng.New(instance)
+
+		result := dork.
+			Site("example.com").
+			InURL("admin").
+			FileType("pdf").
+			Ext("sql").
+			InTitle("index of").
+			InText("password").
+			String()
+
+		assert.Equal("site:example.com inurl:\"admin\" filetype:\"pdf\" ext:sql intitle:\"index of\" intext:\"password\"", result, "they should be equal")
+	})
+
+	t.Run("should handle or, groups and exclusions correctly", func(t *testing.T) {
+		dork = searxng.New(instance)
+
+		result := dork.
+			Group(searxng.New(instance).Site("a.com").Or().Site("b.com")).
+			Exclude(searxng.New(instance).InURL("login")).
+			String()
+
+		assert.Equal("(site:a.com | site:b.com) -inurl:\"login\"", result, "they should be equal")
+		assert.True(errors.Is(searxng.New(instance).Exclude(searxng.New(instance).Group(searxng.New(instance).Site("a.com"))).Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+	})
+
+	t.Run("should prefix the request with bangs", func(t *testing.T) {
+		dork = searxng.New(instance).
+			EngineBang("go").
+			CategoryBang("social media").
+			Site("example.com")
+
+		assert.Equal("!go !social_media site:example.com", dork.String(), "they should be equal")
+		assert.Equal([]string{"go", "social_media"}, dork.Bangs(), "they should be equal")
+		assert.Equal(query.Query{query.Operator{Name: query.OpSite, Value: "example.com"}}, dork.Query(), "they should be equal")
+		assert.Equal("!images", searxng.New(instance).CategoryBang("images").String(), "they should be equal")
+	})
+
+	t.Run("should reject invalid bangs", func(t *testing.T) {
+		dork = searxng.New(instance).EngineBang("a b")
+
+		assert.EqualError(dork.Err(), "invalid parameter: bang=a b")
+	})
+
+	t.Run("should target the search endpoint of the instance", func(t *testing.T) {
+		dork = searxng.New("http://localhost:8888/searx?theme=simple").Site("example.com")
+
+		assert.Equal("http://localhost:8888/searx/search?q=site%3Aexample.com&theme=simple", dork.URL(), "they should be equal")
+
+		dork = searxng.New("http://localhost:8888/searx/").Site("example.com")
+
+		assert.Equal("http://localhost:8888/searx/search?q=site%3Aexample.com", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should reject invalid instances", func(t *testing.T) {
+		for _, u := range []string{"", "searx.example.org", "http://[::1"} {
+			_, err := searxng.New(u).Site("example.com").URLE()
+
+			assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+			assert.Equal("", searxng.New(u).URL(), "they should be equal")
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should recognize leading bangs", func(t *testing.T) {
+		dork = searxng.New(instance).
+			EngineBang("ddg").
+			CategoryBang("it").
+			Site("example.com").
+			Or().
+			InTitle("admin")
+
+		result, err := searxng.Parse(instance, dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+	})
+
+	t.Run("should keep invalid bangs as terms", func(t *testing.T) {
+		result, err := searxng.Parse(instance, "!go !bad$bang site:a.com")
+
+		assert.Nil(err)
+		assert.Nil(result.Err())
+		assert.Equal("!go !bad$bang site:a.com", result.String(), "they should be equal")
+		assert.Equal(query.Query{query.Term{Text: "!bad$bang"}, query.Operator{Name: query.OpSite, Value: "a.com"}}, result.Query(), "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should render a query built for Google", func(t *testing.T) {
+		q := googlesearch.New().
+			Site("example.com").
+			InText("admin").
+			Query()
+
+		assert.Equal(googlesearch.Render(q), searxng.Render(q), "they should be equal")
+		assert.Equal("https://searx.example.org/search?q=site%3Aexample.com+intext%3A%22admin%22", searxng.FromQuery(instance, q).URL(), "they should be equal")
+		assert.False(searxng.Supports(query.OpCache))
+	})
+
+	t.Run("should use the Google Search syntax", func(t *testing.T) {
+		for _, name := range []string{query.OpSite, query.OpInURL, query.OpFileType, query.OpExt, query.OpInTitle, query.OpInText} {
+			q := query.Query{query.Not{Node: query.Operator{Name: name, Value: "a"}}, query.Or{}, query.Term{Text: "b"}}
+
+			assert.True(searxng.Supports(name), name)
+			assert.Equal(googlesearch.Render(q), searxng.Render(q), "they should be equal")
+		}
+		assert.False(searxng.HasFeature(query.Proximity))
+		assert.True(googlesearch.HasFeature(query.Proximity))
+	})
+}
+
+func TestInstance(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should search a SearXNG instance", func(t *testing.T) {
+		type result struct {
+			Query   string `json:"query"`
+			Results []struct {
+				URL string `json:"url"`
+			} `json:"results"`
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/search" || r.URL.Query().Get("format") != "json" {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"query":   r.URL.Query().Get("q"),
+				"results": []map[string]string{{"url": "https://example.com/" + r.URL.Query().Get("categories")}},
+			})
+		}))
+		defer server.Close()
+
+		dork = searxng.New(server.URL).
+			Site("example.com").
+			InURL("admin").
+			Categories("general", "it").
+			Format(searxng.JSON)
+
+		res, err := http.Get(dork.URL())
+		assert.Nil(err)
+		defer res.Body.Close()
+
+		var body result
+		assert.Equal(http.StatusOK, res.StatusCode, "they should be equal")
+		assert.Nil(json.NewDecoder(res.Body).Decode(&body))
+		assert.Equal("site:example.com inurl:\"admin\"", body.Query, "they should be equal")
+		assert.Len(body.Results, 1)
+		assert.Equal("https://example.com/general,it", body.Results[0].URL, "they should be equal")
+	})
+}
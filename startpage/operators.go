@@ -0,0 +1,38 @@
+package startpage
+
+import (
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// overrides describes the operators Startpage honors differently than Google, indexed by operator name.
+var overrides = map[string]query.OperatorInfo{
+	query.OpDefine:   {Name: query.OpDefine, Status: query.Degraded, Note: "Google answer boxes are not shown"},
+	query.OpWeather:  {Name: query.OpWeather, Status: query.Degraded, Note: "Google answer boxes are not shown"},
+	query.OpSource:   {Name: query.OpSource, Status: query.Deprecated, Note: "only honored by Google News, which Startpage doesn't relay"},
+	query.OpBook:     {Name: query.OpBook, Status: query.Deprecated},
+	query.OpMaps:     {Name: query.OpMaps, Status: query.Deprecated},
+	query.OpLocation: {Name: query.OpLocation, Status: query.Deprecated},
+}
+
+// operators describes the status of operators on Startpage, indexed by operator name.
+// Requests are relayed to Google, so operators Google no longer honors are not honored either.
+var operators = func() map[string]query.OperatorInfo {
+	table := googlesearch.Operators()
+	for name, info := range overrides {
+		table[name] = info
+	}
+
+	return table
+}()
+
+// Status returns the status of an operator on Startpage, such as query.OpCache.
+func Status(operator string) (query.OperatorInfo, bool) {
+	info, ok := operators[operator]
+	return info, ok
+}
+
+// Warnings returns a diagnostic for each operator of the request that Startpage doesn't fully honor.
+func (e *Startpage) Warnings() []query.Warning {
+	return query.Warnings(e.nodes, operators)
+}
@@ -0,0 +1,44 @@
+package startpage_test
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/startpage"
+)
+
+func TestOperators(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should describe operator status", func(t *testing.T) {
+		info, ok := startpage.Status(query.OpCache)
+
+		assert.True(ok)
+		assert.Equal(query.Deprecated, info.Status, "they should be equal")
+
+		info, ok = startpage.Status(query.OpDefine)
+
+		assert.True(ok)
+		assert.Equal(query.Degraded, info.Status, "they should be equal")
+
+		info, ok = startpage.Status(query.OpSource)
+
+		assert.True(ok)
+		assert.Equal(query.Deprecated, info.Status, "they should be equal")
+	})
+
+	t.Run("should warn about operators Google no longer honors", func(t *testing.T) {
+		q := query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.com"},
+			query.Operator{Name: query.OpRelated, Value: "example.com", Quoted: true},
+		}
+		dork = startpage.FromQuery(q)
+
+		warnings := dork.Warnings()
+
+		assert.Len(warnings, 1)
+		assert.Equal("tag 1: related is deprecated since 2023-07", warnings[0].String(), "they should be equal")
+		assert.Nil(startpage.New().Site("example.com").InText("admin").Warnings())
+	})
+}
@@ -0,0 +1,54 @@
+package startpage
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// TimeRange restricts results to a recent period, used as the "with_date" URL parameter.
+type TimeRange string
+
+// Time ranges
+const (
+	PastDay   TimeRange = "d"
+	PastWeek  TimeRange = "w"
+	PastMonth TimeRange = "m"
+	PastYear  TimeRange = "y"
+)
+
+var languageName = regexp.MustCompile(`^[a-z_]+$`)
+
+func (e *Startpage) set(param string, value string) *Startpage {
+	if e.params == nil {
+		e.params = url.Values{}
+	}
+	e.params.Set(param, value)
+	return e
+}
+
+func (e *Startpage) invalid(param string, value interface{}) *Startpage {
+	if e.err == nil {
+		e.err = fmt.Errorf("%w: %s=%v", query.ErrInvalidParameter, param, value)
+	}
+	return e
+}
+
+// Language restricts results to a language, using its Startpage name such as "english" or "francais" (language parameter).
+func (e *Startpage) Language(lang string) *Startpage {
+	if !languageName.MatchString(lang) {
+		return e.invalid("language", lang)
+	}
+	return e.set("language", lang)
+}
+
+// TimeRange restricts results to a recent period (with_date parameter).
+func (e *Startpage) TimeRange(r TimeRange) *Startpage {
+	switch r {
+	case PastDay, PastWeek, PastMonth, PastYear:
+		return e.set("with_date", string(r))
+	}
+	return e.invalid("with_date", r)
+}
@@ -0,0 +1,30 @@
+package startpage_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/startpage"
+)
+
+func TestParams(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should set the language and time range", func(t *testing.T) {
+		dork = startpage.New().Site("example.com").Language("francais").TimeRange(startpage.PastMonth)
+
+		assert.Nil(dork.Err())
+		assert.Equal("https://www.startpage.com/sp/search?language=francais&query=site%3Aexample.com&with_date=m", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		dork = startpage.New().Language("fr-FR")
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidParameter), "it should be an invalid parameter error")
+		assert.EqualError(dork.Err(), "invalid parameter: language=fr-FR")
+
+		assert.EqualError(startpage.New().TimeRange("h").Err(), "invalid parameter: with_date=h")
+	})
+}
@@ -0,0 +1,281 @@
+package startpage
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "startpage"
+
+const searchURL = "https://www.startpage.com/sp/search"
+
+// Startpage is the Startpage search implementation for Dorkgen
+type Startpage struct {
+	nodes   query.Query
+	params  url.Values
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of Startpage
+type Option func(*Startpage)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *Startpage) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *Startpage) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of Startpage
+func New(opts ...Option) *Startpage {
+	e := &Startpage{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of Startpage from a query tree
+func FromQuery(q query.Query) *Startpage {
+	return &Startpage{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of Startpage.
+// Any Google Search dork is accepted.
+func Parse(dork string) (*Startpage, error) {
+	g, err := googlesearch.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Startpage{nodes: g.Query()}, nil
+}
+
+// Render converts a query tree to Startpage syntax, which is the one of Google Search
+func Render(q query.Query) string {
+	return googlesearch.Render(q)
+}
+
+// Supports reports whether the operator is available in Startpage
+func Supports(operator string) bool {
+	return googlesearch.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Startpage
+func HasFeature(f query.Feature) bool {
+	return googlesearch.HasFeature(f)
+}
+
+func (e *Startpage) add(n query.Node) *Startpage {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *Startpage) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *Startpage) operator(name string, value string, quotes bool) *Startpage {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *Startpage) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *Startpage) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *Startpage) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+// Operators such as allintitle can't be combined with other operators.
+func (e *Startpage) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return googlesearch.FromQuery(e.nodes).Validate()
+}
+
+// String converts all tags to a single request
+func (e *Startpage) String() string {
+	return googlesearch.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values, including URL parameters
+func (e *Startpage) QueryValues() url.Values {
+	params := url.Values{}
+	for k, v := range e.params {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("query", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded Startpage URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *Startpage) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded Startpage URL, or returns an error if the base URL is invalid.
+func (e *Startpage) URLE() (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, e.QueryValues())
+}
+
+// Site specifically searches that particular site and lists all the results for that site.
+func (e *Startpage) Site(site string) *Startpage {
+	return e.operator(query.OpSite, site, false)
+}
+
+// Or puts an OR operator in the request
+func (e *Startpage) Or() *Startpage {
+	return e.add(query.Or{})
+}
+
+// InText searches for the occurrences of keywords all at once or one at a time.
+func (e *Startpage) InText(text string) *Startpage {
+	return e.operator(query.OpInText, text, true)
+}
+
+// InURL searches for a URL matching one of the keywords.
+func (e *Startpage) InURL(url string) *Startpage {
+	return e.operator(query.OpInURL, url, true)
+}
+
+// FileType searches for a particular filetype mentioned in the query.
+func (e *Startpage) FileType(filetype string) *Startpage {
+	return e.operator(query.OpFileType, filetype, true)
+}
+
+// Ext searches for a particular file extension mentioned in the query.
+func (e *Startpage) Ext(ext string) *Startpage {
+	return e.operator(query.OpExt, ext, false)
+}
+
+// InTitle searches for occurrences of keywords in title all or one.
+func (e *Startpage) InTitle(value string) *Startpage {
+	return e.operator(query.OpInTitle, value, true)
+}
+
+// AllInText searches for pages with all the keywords in their text.
+// It can't be combined with other operators.
+func (e *Startpage) AllInText(text string) *Startpage {
+	return e.operator(query.OpAllInText, text, true)
+}
+
+// AllInTitle searches for pages with all the keywords in their title.
+// It can't be combined with other operators.
+func (e *Startpage) AllInTitle(text string) *Startpage {
+	return e.operator(query.OpAllInTitle, text, true)
+}
+
+// AllInURL searches for pages with all the keywords in their URL.
+// It can't be combined with other operators.
+func (e *Startpage) AllInURL(text string) *Startpage {
+	return e.operator(query.OpAllInURL, text, true)
+}
+
+// Before searches for results published before the given date.
+func (e *Startpage) Before(date time.Time) *Startpage {
+	return e.operator(query.OpBefore, date.Format(query.DateFormat), false)
+}
+
+// After searches for results published after the given date.
+func (e *Startpage) After(date time.Time) *Startpage {
+	return e.operator(query.OpAfter, date.Format(query.DateFormat), false)
+}
+
+// Between searches for results published between the given dates, using both after and before operators.
+func (e *Startpage) Between(from, to time.Time) *Startpage {
+	if to.Before(from) {
+		e.fail(query.ErrInvalidDateRange)
+		return e
+	}
+
+	return e.After(from).Before(to)
+}
+
+// Phrase searches for an exact phrase, without any operator.
+func (e *Startpage) Phrase(text string) *Startpage {
+	text, err := query.Sanitize(text, true, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Phrase{Text: text})
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Startpage supports excluding a group as a whole.
+func (e *Startpage) Exclude(tags *Startpage) *Startpage {
+	nodes, err := query.Exclude(tags.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *Startpage) Not(tag *Startpage) *Startpage {
+	n, err := query.Negate(tag.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *Startpage) Group(tags *Startpage) *Startpage {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *Startpage) Plain(value string) *Startpage {
+	return e.add(query.Term{Text: value})
+}
@@ -0,0 +1,132 @@
+package startpage_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/startpage"
+)
+
+var dork *startpage.Startpage
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = startpage.New()
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal("https://www.startpage.com/sp/search?query=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = startpage.New()
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal("site:example.com", result, "they should be equal")
+	})
+
+	t.Run("should handle operators correctly", func(t *testing.T) {
+		dork = startpage.New()
+
+		result := dork.
+			Site("example.com").
+			InURL("login").
+			FileType("pdf").
+			Ext("sql").
+			InTitle("index of").
+			InText("password").
+			Phrase("internal use only").
+			Between(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).
+			String()
+
+		assert.Equal("site:example.com inurl:\"login\" filetype:\"pdf\" ext:sql intitle:\"index of\" intext:\"password\" \"internal use only\" after:2020-01-01 before:2021-01-01", result, "they should be equal")
+	})
+
+	t.Run("should handle or and groups correctly", func(t *testing.T) {
+		dork = startpage.New()
+
+		result := dork.
+			Group(startpage.New().Site("a.com").Or().Site("b.com")).
+			InTitle("admin").
+			String()
+
+		assert.Equal("(site:a.com | site:b.com) intitle:\"admin\"", result, "they should be equal")
+	})
+
+	t.Run("should handle exclusions correctly", func(t *testing.T) {
+		dork = startpage.New()
+
+		result := dork.
+			Site("example.com").
+			Not(startpage.New().Group(startpage.New().FileType("pdf").Or().FileType("doc"))).
+			String()
+
+		assert.Equal("site:example.com -(filetype:\"pdf\" | filetype:\"doc\")", result, "they should be equal")
+	})
+
+	t.Run("should reject reversed date ranges", func(t *testing.T) {
+		dork = startpage.New().Between(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidDateRange), "it should be an invalid date range error")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = startpage.New(startpage.WithBaseURL("http://localhost:8080/do/search?cat=web")).Site("example.com")
+
+		assert.Equal("http://localhost:8080/do/search?cat=web&query=site%3Aexample.com", dork.URL(), "they should be equal")
+
+		_, err := startpage.New(startpage.WithBaseURL("search")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+
+	t.Run("should reject combined standalone operators", func(t *testing.T) {
+		dork = startpage.New().AllInTitle("admin login").Site("example.com")
+
+		assert.True(errors.Is(dork.Validate(), query.ErrNonCombinable), "it should be a non-combinable operator error")
+		assert.Nil(startpage.New().AllInURL("admin login").Validate())
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should accept Google dorks", func(t *testing.T) {
+		g := googlesearch.New().
+			Site("example.com").
+			Cache("example.com").
+			Around("admin", 3, "password").
+			Exclude(googlesearch.New().InAnchor("login"))
+
+		result, err := startpage.Parse(g.String())
+
+		assert.Nil(err)
+		assert.Equal(g.Query(), result.Query(), "they should be equal")
+		assert.Equal(g.String(), result.String(), "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should pass Google syntax through", func(t *testing.T) {
+		q := googlesearch.New().
+			Site("example.com").
+			Info("example.com").
+			Query()
+
+		assert.Equal(googlesearch.Render(q), startpage.Render(q), "they should be equal")
+		assert.Equal("site:example.com info:\"example.com\"", startpage.FromQuery(q).String(), "they should be equal")
+		assert.True(startpage.Supports(query.OpAllInAnchor))
+		assert.False(startpage.Supports(query.OpInPage))
+	})
+}
@@ -0,0 +1,736 @@
+package dorkgen
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sundowndev/dorkgen/baidu"
+	"github.com/sundowndev/dorkgen/bingsearch"
+	"github.com/sundowndev/dorkgen/bravesearch"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/githubsearch"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/startpage"
+	"github.com/sundowndev/dorkgen/yahoosearch"
+	"github.com/sundowndev/dorkgen/yandex"
+)
+
+// ErrUnknownEngine is returned when translating to an engine that doesn't exist.
+var ErrUnknownEngine = errors.New("unknown search engine")
+
+// IncompatibilityKind describes how an operator was handled during a translation.
+type IncompatibilityKind int
+
+const (
+	// Dropped means the operator has no equivalent and was removed from the query.
+	Dropped IncompatibilityKind = iota
+	// Approximated means the operator was replaced by a close but not identical one.
+	Approximated
+	// MovedToParams means the operator was removed from the query and set as a URL parameter instead.
+	MovedToParams
+)
+
+func (k IncompatibilityKind) String() string {
+	switch k {
+	case Dropped:
+		return "dropped"
+	case Approximated:
+		return "approximated"
+	case MovedToParams:
+		return "moved to URL parameters"
+	}
+
+	return "unknown"
+}
+
+// Incompatibility reports an operator or a URL parameter that couldn't be translated as-is.
+type Incompatibility struct {
+	// Operator is the name of the operator in the source query, the tag type of a primitive such as "around",
+	// or the name of the URL parameter if Param is true
+	Operator string
+	// Value is the value of the operator or URL parameter in the source dork
+	Value string
+	Kind  IncompatibilityKind
+	// Replacement is the approximating operator or the URL parameter, if any
+	Replacement string
+	// Param is true if the incompatibility is about a URL parameter of the source dork
+	Param bool
+}
+
+func (i Incompatibility) String() string {
+	sep := ":"
+	if i.Param {
+		sep = "="
+	}
+
+	if i.Replacement == "" {
+		return fmt.Sprintf("%s%s%s %s", i.Operator, sep, i.Value, i.Kind)
+	}
+
+	return fmt.Sprintf("%s%s%s %s (%s)", i.Operator, sep, i.Value, i.Kind, i.Replacement)
+}
+
+// approximations lists operators that can replace another one when the target engine doesn't support it.
+var approximations = map[string]string{
+	query.OpAllInText:   query.OpInText,
+	query.OpAllInTitle:  query.OpInTitle,
+	query.OpAllInURL:    query.OpInURL,
+	query.OpAllInAnchor: query.OpInAnchor,
+	query.OpExt:         query.OpFileType,
+}
+
+type target struct {
+	supports func(operator string) bool
+	// features reports whether a primitive other than operators, such as proximity searches, is supported
+	features func(f query.Feature) bool
+	build    func(q query.Query) Engine
+	// clone copies a dork of the target engine along with its options, if the builder supports it
+	clone func(e Engine) Engine
+	// negateGroups is true if a group can be excluded as a whole
+	negateGroups bool
+	// params lists operators that can be replaced by a URL parameter
+	params map[string]param
+	// conversions lists URL parameters of other engines having an equivalent, indexed by source engine name
+	conversions map[string][]conversion
+}
+
+// param is a URL parameter replacing one or more operators.
+type param struct {
+	name string
+	// set sets the parameter using the values of the moved operators, indexed by operator name
+	set func(e Engine, values map[string]string) error
+}
+
+// conversion sets URL parameters of the target engine from URL parameters of the source dork.
+type conversion struct {
+	// from lists the source parameters used by the conversion
+	from []string
+	set  func(e Engine, params url.Values) error
+}
+
+// banger is implemented by builders whose request can be prefixed by !bangs, which only have a meaning on their engine.
+type banger interface {
+	Bangs() []string
+}
+
+var targets = map[string]target{
+	googlesearch.EngineName: {
+		supports:     googlesearch.Supports,
+		features:     googlesearch.HasFeature,
+		build:        func(q query.Query) Engine { return googlesearch.FromQuery(q) },
+		clone:        func(e Engine) Engine { return e.(*googlesearch.GoogleSearch).Clone() },
+		negateGroups: true,
+		params: map[string]param{
+			query.OpRegion: {name: "cr", set: func(e Engine, values map[string]string) error {
+				return e.(*googlesearch.GoogleSearch).CountryRestrict(values[query.OpRegion]).Err()
+			}},
+			query.OpLanguage: {name: "lr", set: func(e Engine, values map[string]string) error {
+				return e.(*googlesearch.GoogleSearch).LanguageRestrict(values[query.OpLanguage]).Err()
+			}},
+		},
+		conversions: map[string][]conversion{
+			duckduckgo.EngineName: {
+				{from: []string{"df"}, set: setGoogleTimeRange},
+				{from: []string{"kl"}, set: setGoogleRestrictions},
+				{from: []string{"kp"}, set: setGoogleSafeSearch},
+				{from: []string{"ia", "iax", "iar"}, set: setGoogleResultType},
+			},
+		},
+	},
+	duckduckgo.EngineName: {
+		supports: duckduckgo.Supports,
+		features: duckduckgo.HasFeature,
+		build:    func(q query.Query) Engine { return duckduckgo.FromQuery(q) },
+		clone:    func(e Engine) Engine { return e.(*duckduckgo.DuckDuckGo).Clone() },
+		params: map[string]param{
+			query.OpAfter:  {name: "df", set: setDuckDuckGoDateRange},
+			query.OpBefore: {name: "df", set: setDuckDuckGoDateRange},
+		},
+		conversions: map[string][]conversion{
+			googlesearch.EngineName: {
+				{from: []string{"tbs"}, set: setDuckDuckGoDateFilter},
+				{from: []string{"cr", "lr"}, set: setDuckDuckGoRegion},
+				{from: []string{"safe"}, set: setDuckDuckGoSafeSearch},
+				{from: []string{"tbm"}, set: setDuckDuckGoVertical},
+			},
+		},
+	},
+	bingsearch.EngineName: {
+		supports:     bingsearch.Supports,
+		features:     bingsearch.HasFeature,
+		build:        func(q query.Query) Engine { return bingsearch.FromQuery(q) },
+		negateGroups: true,
+	},
+	yahoosearch.EngineName: {
+		supports: yahoosearch.Supports,
+		features: yahoosearch.HasFeature,
+		build:    func(q query.Query) Engine { return yahoosearch.FromQuery(q) },
+	},
+	yandex.EngineName: {
+		supports:     yandex.Supports,
+		features:     yandex.HasFeature,
+		build:        func(q query.Query) Engine { return yandex.FromQuery(q) },
+		negateGroups: true,
+		conversions: map[string][]conversion{
+			yandex.EngineName: {
+				{from: []string{"lr"}, set: func(e Engine, params url.Values) error {
+					id, err := strconv.Atoi(params.Get("lr"))
+					if err != nil {
+						return err
+					}
+					return e.(*yandex.Yandex).Region(id).Err()
+				}},
+			},
+		},
+	},
+	baidu.EngineName: {
+		supports: baidu.Supports,
+		features: baidu.HasFeature,
+		build:    func(q query.Query) Engine { return baidu.FromQuery(q) },
+	},
+	bravesearch.EngineName: {
+		supports: bravesearch.Supports,
+		features: bravesearch.HasFeature,
+		build:    func(q query.Query) Engine { return bravesearch.FromQuery(q) },
+		conversions: map[string][]conversion{
+			bravesearch.EngineName: {
+				{from: []string{"tf"}, set: func(e Engine, params url.Values) error {
+					return e.(*bravesearch.BraveSearch).Freshness(bravesearch.Freshness(params.Get("tf"))).Err()
+				}},
+				{from: []string{"offset"}, set: func(e Engine, params url.Values) error {
+					page, err := strconv.Atoi(params.Get("offset"))
+					if err != nil {
+						return err
+					}
+					return e.(*bravesearch.BraveSearch).Offset(page).Err()
+				}},
+			},
+		},
+	},
+	startpage.EngineName: {
+		supports:     startpage.Supports,
+		features:     startpage.HasFeature,
+		build:        func(q query.Query) Engine { return startpage.FromQuery(q) },
+		negateGroups: true,
+		conversions: map[string][]conversion{
+			startpage.EngineName: {
+				{from: []string{"language"}, set: func(e Engine, params url.Values) error {
+					return e.(*startpage.Startpage).Language(params.Get("language")).Err()
+				}},
+				{from: []string{"with_date"}, set: func(e Engine, params url.Values) error {
+					return e.(*startpage.Startpage).TimeRange(startpage.TimeRange(params.Get("with_date"))).Err()
+				}},
+			},
+		},
+	},
+	githubsearch.EngineName: {
+		supports:     githubsearch.Supports,
+		features:     githubsearch.HasFeature,
+		build:        func(q query.Query) Engine { return githubsearch.FromQuery(q) },
+		negateGroups: true,
+	},
+}
+
+// Translate converts a dork to the given search engine, such as "google" or "duckduckgo".
+// Operators that are not supported by the target engine are approximated, moved to URL parameters
+// or dropped, and reported in the returned list of incompatibilities.
+// URL parameters of the dork are converted to their equivalent on the target engine, or reported as dropped.
+// So are the !bangs of DuckDuckGo and SearXNG dorks, which are reported as a "bang" URL parameter.
+// Excluded groups are rewritten using De Morgan's laws for engines that can't exclude a group as a whole.
+// Google and DuckDuckGo dorks translated to their own engine are cloned, keeping their options.
+// The translated dork is validated against the target engine: if it's not well-formed, such as a Yandex dork
+// starting with an exclusion once the operators before it were dropped, it is returned along with the validation error.
+func Translate(src Engine, targetName string) (Engine, []Incompatibility, error) {
+	t, ok := targets[targetName]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownEngine, targetName)
+	}
+
+	if t.clone != nil && src.Name() == targetName {
+		return t.clone(src), nil, nil
+	}
+
+	tr := &translator{target: t, moved: map[string]string{}}
+	q := tr.translate(src.Query())
+
+	e := t.build(q)
+	tr.setParams(e)
+	tr.convertParams(src, e)
+	if b, ok := src.(banger); ok {
+		for _, bang := range b.Bangs() {
+			tr.reportParam("bang", bang)
+		}
+	}
+
+	return e, tr.incompatibilities, e.Validate()
+}
+
+// sourceParams returns the URL parameters of a dork, except the query and the parameters set on every request.
+func sourceParams(src Engine) url.Values {
+	params := src.QueryValues()
+
+	defaults := url.Values{"q": nil}
+	if t, ok := targets[src.Name()]; ok {
+		defaults = t.build(nil).QueryValues()
+	}
+	for name := range defaults {
+		params.Del(name)
+	}
+
+	return params
+}
+
+type translator struct {
+	target            target
+	incompatibilities []Incompatibility
+	// moved holds the value of operators moved to URL parameters, indexed by operator name
+	moved map[string]string
+	// depth is the number of exclusions, groups and proximity searches around the current node.
+	// Only top-level operators can be moved to URL parameters without changing the meaning of the query.
+	depth int
+}
+
+// setParams sets the URL parameters replacing moved operators.
+// Parameters are tried on an empty dork first, so a failed one doesn't leave the result in error.
+// Operators are reported as dropped if their value can't be used as a parameter.
+func (tr *translator) setParams(e Engine) {
+	failed := map[string]bool{}
+	done := map[string]bool{}
+
+	for name := range tr.moved {
+		p := tr.target.params[name]
+		if done[p.name] {
+			continue
+		}
+		done[p.name] = true
+
+		if err := p.set(tr.target.build(nil), tr.moved); err != nil {
+			failed[p.name] = true
+			continue
+		}
+		_ = p.set(e, tr.moved)
+	}
+
+	for i, inc := range tr.incompatibilities {
+		if inc.Kind == MovedToParams && failed[inc.Replacement] {
+			tr.incompatibilities[i].Kind = Dropped
+			tr.incompatibilities[i].Replacement = ""
+		}
+	}
+}
+
+// convertParams sets the URL parameters of the source dork that have an equivalent on the target engine.
+// Conversions are tried on an empty dork first, so a failed one doesn't leave the result in error.
+// Other parameters are reported as dropped.
+func (tr *translator) convertParams(src Engine, e Engine) {
+	params := sourceParams(src)
+
+	for _, c := range tr.target.conversions[src.Name()] {
+		values := url.Values{}
+		for _, name := range c.from {
+			if v, ok := params[name]; ok {
+				values[name] = v
+				params.Del(name)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := c.set(tr.target.build(nil), values); err != nil {
+			for _, name := range c.from {
+				if _, ok := values[name]; ok {
+					tr.reportParam(name, values.Get(name))
+				}
+			}
+			continue
+		}
+		_ = c.set(e, values)
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tr.reportParam(name, params.Get(name))
+	}
+}
+
+func (tr *translator) translate(q query.Query) query.Query {
+	result := make(query.Query, 0, len(q))
+	for i, n := range q {
+		// Nodes replaced by several ones are spliced, unless a group is needed to keep them apart from OR and AND.
+		nodes := tr.nodes(n)
+		if len(nodes) > 1 && nextToConnective(q, i) {
+			nodes = query.Query{query.Group{Nodes: nodes}}
+		}
+		result = append(result, nodes...)
+	}
+
+	return compact(result)
+}
+
+func nextToConnective(q query.Query, i int) bool {
+	return (i > 0 && isConnective(q[i-1])) || (i+1 < len(q) && isConnective(q[i+1]))
+}
+
+// nodes translates a node into the nodes replacing it, which are implicitly joined with AND.
+func (tr *translator) nodes(n query.Node) query.Query {
+	switch v := n.(type) {
+	case query.Around:
+		return tr.around(v)
+	case query.Not:
+		return tr.not(v)
+	}
+
+	if n = tr.node(n); n != nil {
+		return query.Query{n}
+	}
+	return nil
+}
+
+// node translates a single node, returning nil if it must be removed.
+func (tr *translator) node(n query.Node) query.Node {
+	switch v := n.(type) {
+	case query.Operator:
+		return tr.operator(v)
+	case query.Not, query.Around:
+		return group(tr.nodes(v))
+	case query.Group:
+		tr.depth++
+		defer func() { tr.depth-- }()
+
+		if nodes := tr.translate(v.Nodes); len(nodes) > 0 {
+			return query.Group{Nodes: nodes}
+		}
+		return nil
+	case query.Wildcard:
+		if !tr.target.features(query.Wildcards) {
+			tr.report(query.Operator{Name: query.TagWildcard, Value: "*"}, Dropped, "")
+			return nil
+		}
+	case query.Range:
+		if !tr.target.features(query.Ranges) {
+			tr.report(query.Operator{Name: query.TagRange, Value: v.Low + ".." + v.High}, Dropped, "")
+			return nil
+		}
+	case query.Regex:
+		if !tr.target.features(query.Regexes) {
+			tr.report(query.Operator{Name: query.TagRegex, Value: v.Pattern}, Dropped, "")
+			return nil
+		}
+	}
+
+	return n
+}
+
+// around translates a proximity search. If the target engine doesn't support it,
+// it is approximated by requiring both operands anywhere in the page.
+func (tr *translator) around(a query.Around) query.Query {
+	tr.depth++
+	left, right := tr.node(a.Left), tr.node(a.Right)
+	tr.depth--
+
+	if left == nil || right == nil {
+		tr.report(query.Operator{Name: query.TagAround, Value: strconv.Itoa(a.Distance)}, Dropped, "")
+		if left != nil {
+			return query.Query{left}
+		}
+		if right != nil {
+			return query.Query{right}
+		}
+		return nil
+	}
+
+	if !tr.target.features(query.Proximity) {
+		tr.report(query.Operator{Name: query.TagAround, Value: strconv.Itoa(a.Distance)}, Approximated, query.TagAnd)
+		return query.Query{left, right}
+	}
+
+	return query.Query{query.Around{Left: left, Right: right, Distance: a.Distance}}
+}
+
+// not translates an exclusion. If the target engine can't exclude a group as a whole,
+// the group is rewritten using De Morgan's laws, which doesn't change the meaning of the query.
+func (tr *translator) not(v query.Not) query.Query {
+	tr.depth++
+	child := tr.node(v.Node)
+	tr.depth--
+
+	if child == nil {
+		return nil
+	}
+	if g, ok := child.(query.Group); ok && !tr.target.negateGroups {
+		return deMorgan(g.Nodes)
+	}
+
+	return query.Query{query.Not{Node: child}}
+}
+
+// deMorgan returns the negation of a query as nodes implicitly joined with AND:
+// -(a | b) becomes -a -b and -(a b) becomes (-a | -b).
+func deMorgan(q query.Query) query.Query {
+	var result, negated query.Query
+	for i, n := range q {
+		if !isConnective(n) {
+			if len(negated) > 0 {
+				negated = append(negated, query.Or{})
+			}
+			negated = append(negated, negate(n))
+		}
+
+		if _, or := n.(query.Or); (or || i == len(q)-1) && len(negated) > 0 {
+			result = append(result, group(negated))
+			negated = nil
+		}
+	}
+
+	return result
+}
+
+// negate returns the negation of a single node without excluding a group as a whole.
+func negate(n query.Node) query.Node {
+	switch v := n.(type) {
+	case query.Not:
+		return v.Node
+	case query.Group:
+		return group(deMorgan(v.Nodes))
+	}
+
+	return query.Not{Node: n}
+}
+
+// group returns the only node of a query, or a group of its nodes.
+func group(q query.Query) query.Node {
+	switch len(q) {
+	case 0:
+		return nil
+	case 1:
+		return q[0]
+	}
+
+	return query.Group{Nodes: q}
+}
+
+func (tr *translator) operator(op query.Operator) query.Node {
+	if tr.target.supports(op.Name) {
+		return op
+	}
+
+	if name, ok := approximations[op.Name]; ok && tr.target.supports(name) {
+		tr.report(op, Approximated, name)
+		return query.Operator{Name: name, Value: op.Value, Quoted: op.Quoted}
+	}
+
+	if p, ok := tr.target.params[op.Name]; ok && tr.depth == 0 {
+		if _, exists := tr.moved[op.Name]; !exists {
+			tr.report(op, MovedToParams, p.name)
+			tr.moved[op.Name] = op.Value
+			return nil
+		}
+	}
+
+	tr.report(op, Dropped, "")
+	return nil
+}
+
+func (tr *translator) report(op query.Operator, kind IncompatibilityKind, replacement string) {
+	tr.incompatibilities = append(tr.incompatibilities, Incompatibility{
+		Operator:    op.Name,
+		Value:       op.Value,
+		Kind:        kind,
+		Replacement: replacement,
+	})
+}
+
+func (tr *translator) reportParam(name, value string) {
+	tr.incompatibilities = append(tr.incompatibilities, Incompatibility{
+		Operator: name,
+		Value:    value,
+		Kind:     Dropped,
+		Param:    true,
+	})
+}
+
+// setDuckDuckGoDateRange replaces the after and before operators with the df parameter.
+// DuckDuckGo has no open-ended date range, so a missing before operator defaults to the current date,
+// and a missing after operator to the Unix epoch, before any page was published on the web.
+func setDuckDuckGoDateRange(e Engine, values map[string]string) error {
+	var err error
+
+	after := time.Unix(0, 0).UTC()
+	if value, ok := values[query.OpAfter]; ok {
+		if after, err = query.ParseDate(value); err != nil {
+			return err
+		}
+	}
+
+	before := time.Now()
+	if value, ok := values[query.OpBefore]; ok {
+		if before, err = query.ParseDate(value); err != nil {
+			return err
+		}
+	}
+
+	return e.(*duckduckgo.DuckDuckGo).DateRange(after, before).Err()
+}
+
+// setDuckDuckGoDateFilter converts the tbs parameter of Google, either a time range or a custom date range.
+// DuckDuckGo has no equivalent of the past hour.
+func setDuckDuckGoDateFilter(e Engine, params url.Values) error {
+	tbs := params.Get("tbs")
+	if strings.HasPrefix(tbs, "qdr:") {
+		return e.(*duckduckgo.DuckDuckGo).DateFilter(duckduckgo.DateFilter(strings.TrimPrefix(tbs, "qdr:"))).Err()
+	}
+
+	var from, to string
+	for _, field := range strings.Split(tbs, ",") {
+		switch {
+		case strings.HasPrefix(field, "cd_min:"):
+			from = strings.TrimPrefix(field, "cd_min:")
+		case strings.HasPrefix(field, "cd_max:"):
+			to = strings.TrimPrefix(field, "cd_max:")
+		}
+	}
+
+	after, err := time.Parse("1/2/2006", from)
+	if err != nil {
+		return err
+	}
+	before, err := time.Parse("1/2/2006", to)
+	if err != nil {
+		return err
+	}
+
+	return e.(*duckduckgo.DuckDuckGo).DateRange(after, before).Err()
+}
+
+// setDuckDuckGoRegion converts the cr and lr parameters of Google, such as countryFR and lang_fr, to a region such as fr-fr.
+// A missing country or language defaults to the other one.
+func setDuckDuckGoRegion(e Engine, params url.Values) error {
+	country := strings.ToLower(strings.TrimPrefix(params.Get("cr"), "country"))
+	lang := strings.TrimPrefix(params.Get("lr"), "lang_")
+
+	if country == "" {
+		country = lang
+	}
+	if lang == "" {
+		lang = country
+	}
+
+	return e.(*duckduckgo.DuckDuckGo).Region(country + "-" + lang).Err()
+}
+
+// setDuckDuckGoSafeSearch converts the safe parameter of Google.
+func setDuckDuckGoSafeSearch(e Engine, params url.Values) error {
+	modes := map[string]duckduckgo.SafeSearch{
+		string(googlesearch.SafeSearchActive): duckduckgo.SafeSearchStrict,
+		string(googlesearch.SafeSearchOff):    duckduckgo.SafeSearchOff,
+	}
+
+	return e.(*duckduckgo.DuckDuckGo).SafeSearch(modes[params.Get("safe")]).Err()
+}
+
+// setDuckDuckGoVertical converts the tbm parameter of Google.
+func setDuckDuckGoVertical(e Engine, params url.Values) error {
+	verticals := map[string]duckduckgo.Vertical{
+		string(googlesearch.Images): duckduckgo.Images,
+		string(googlesearch.News):   duckduckgo.News,
+		string(googlesearch.Videos): duckduckgo.Videos,
+	}
+
+	return e.(*duckduckgo.DuckDuckGo).Vertical(verticals[params.Get("tbm")]).Err()
+}
+
+// setGoogleTimeRange converts the df parameter of DuckDuckGo, either a date filter or a custom date range.
+func setGoogleTimeRange(e Engine, params url.Values) error {
+	df := params.Get("df")
+
+	i := strings.Index(df, "..")
+	if i < 0 {
+		return e.(*googlesearch.GoogleSearch).TimeRange(googlesearch.TimeRange(df)).Err()
+	}
+
+	after, err := time.Parse("2006-01-02", df[:i])
+	if err != nil {
+		return err
+	}
+	before, err := time.Parse("2006-01-02", df[i+2:])
+	if err != nil {
+		return err
+	}
+
+	return e.(*googlesearch.GoogleSearch).DateRange(after, before).Err()
+}
+
+// setGoogleRestrictions converts the kl parameter of DuckDuckGo, such as fr-fr, to the cr and lr parameters.
+// The wt-wt region means no region, so nothing is restricted.
+func setGoogleRestrictions(e Engine, params url.Values) error {
+	kl := params.Get("kl")
+	if kl == "wt-wt" {
+		return nil
+	}
+
+	parts := strings.SplitN(kl, "-", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("%w: kl=%s", query.ErrInvalidParameter, kl)
+	}
+
+	return e.(*googlesearch.GoogleSearch).CountryRestrict(parts[0]).LanguageRestrict(parts[1]).Err()
+}
+
+// setGoogleSafeSearch converts the kp parameter of DuckDuckGo. Google has no equivalent of the moderate mode.
+func setGoogleSafeSearch(e Engine, params url.Values) error {
+	modes := map[string]googlesearch.SafeSearch{
+		string(duckduckgo.SafeSearchStrict): googlesearch.SafeSearchActive,
+		string(duckduckgo.SafeSearchOff):    googlesearch.SafeSearchOff,
+	}
+
+	return e.(*googlesearch.GoogleSearch).SafeSearch(modes[params.Get("kp")]).Err()
+}
+
+// setGoogleResultType converts the ia parameter of DuckDuckGo and the iax or iar parameter set along with it.
+func setGoogleResultType(e Engine, params url.Values) error {
+	types := map[string]googlesearch.ResultType{
+		string(duckduckgo.Images): googlesearch.Images,
+		string(duckduckgo.News):   googlesearch.News,
+		string(duckduckgo.Videos): googlesearch.Videos,
+	}
+
+	return e.(*googlesearch.GoogleSearch).ResultType(types[params.Get("ia")]).Err()
+}
+
+// compact removes AND and OR operators left without an operand after nodes were dropped.
+func compact(q query.Query) query.Query {
+	result := make(query.Query, 0, len(q))
+	for _, n := range q {
+		if isConnective(n) && (len(result) == 0 || isConnective(result[len(result)-1])) {
+			continue
+		}
+		result = append(result, n)
+	}
+
+	for len(result) > 0 && isConnective(result[len(result)-1]) {
+		result = result[:len(result)-1]
+	}
+
+	return result
+}
+
+func isConnective(n query.Node) bool {
+	switch n.(type) {
+	case query.And, query.Or:
+		return true
+	}
+
+	return false
+}
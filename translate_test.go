@@ -0,0 +1,377 @@
+package dorkgen
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/baidu"
+	"github.com/sundowndev/dorkgen/duckduckgo"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func TestTranslate(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should translate a GoogleSearch to DuckDuckGo", func(t *testing.T) {
+		dork := NewGoogleSearch().
+			Site("example.com").
+			InText("admin").
+			Exclude(NewGoogleSearch().InURL("login"))
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.IsType(&duckduckgo.DuckDuckGo{}, result, "they should be equal")
+		assert.Equal("site:example.com intext:\"admin\" -inurl:\"login\"", result.String(), "they should be equal")
+		assert.Empty(incompatibilities)
+	})
+
+	t.Run("should drop operators without equivalent", func(t *testing.T) {
+		dork := NewGoogleSearch().
+			Site("example.com").
+			Or().
+			Cache("example.com").
+			Exclude(NewGoogleSearch().Book("test")).
+			Group(NewGoogleSearch().Maps("france").Or().Info("example.com"))
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("site:example.com", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpCache, Value: "example.com", Kind: Dropped},
+			{Operator: query.OpBook, Value: "test", Kind: Dropped},
+			{Operator: query.OpMaps, Value: "france", Kind: Dropped},
+			{Operator: query.OpInfo, Value: "example.com", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should approximate operators", func(t *testing.T) {
+		dork := NewGoogleSearch().AllInText("password")
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("intext:\"password\"", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpAllInText, Value: "password", Kind: Approximated, Replacement: query.OpInText},
+		}, incompatibilities, "they should be equal")
+		assert.Equal("allintext:password approximated (intext)", incompatibilities[0].String(), "they should be equal")
+	})
+
+	t.Run("should translate a DuckDuckGo to GoogleSearch", func(t *testing.T) {
+		dork := NewDuckDuckGo().
+			Site("example.com").
+			InTitle("admin").
+			Feed("rss")
+
+		result, incompatibilities, err := Translate(dork, googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.IsType(&googlesearch.GoogleSearch{}, result, "they should be equal")
+		assert.Equal("site:example.com intitle:\"admin\"", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpFeed, Value: "rss", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should move operators to URL parameters", func(t *testing.T) {
+		dork := NewDuckDuckGo().
+			Site("example.com").
+			Location("fr").
+			Language("de")
+
+		result, incompatibilities, err := Translate(dork, googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("https://www.google.com/search?cr=countryFR&lr=lang_de&q=site%3Aexample.com", result.URL(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpRegion, Value: "fr", Kind: MovedToParams, Replacement: "cr"},
+			{Operator: query.OpLanguage, Value: "de", Kind: MovedToParams, Replacement: "lr"},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should move date operators to the DuckDuckGo date filter", func(t *testing.T) {
+		dork := NewGoogleSearch().
+			Site("example.com").
+			Between(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("https://duckduckgo.com/?df=2019-06-01..2020-01-01&q=site%3Aexample.com", result.URL(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpAfter, Value: "2019-06-01", Kind: MovedToParams, Replacement: "df"},
+			{Operator: query.OpBefore, Value: "2020-01-01", Kind: MovedToParams, Replacement: "df"},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should move a single date operator to an open date range", func(t *testing.T) {
+		dork := NewGoogleSearch().Before(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("1970-01-01..2020-01-01", result.QueryValues().Get("df"), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpBefore, Value: "2020-01-01", Kind: MovedToParams, Replacement: "df"},
+		}, incompatibilities, "they should be equal")
+
+		result, _, err = Translate(NewGoogleSearch().After(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("2020-01-01..", result.QueryValues().Get("df")[:12], "they should be equal")
+	})
+
+	t.Run("should drop date operators that can't be moved", func(t *testing.T) {
+		dork := googlesearch.FromQuery(query.Query{
+			query.Operator{Name: query.OpSite, Value: "example.com"},
+			query.Operator{Name: query.OpAfter, Value: "2020-01-01"},
+			query.Operator{Name: query.OpBefore, Value: "2019-01-01"},
+		})
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("https://duckduckgo.com/?q=site%3Aexample.com", result.URL(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpAfter, Value: "2020-01-01", Kind: Dropped},
+			{Operator: query.OpBefore, Value: "2019-01-01", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should drop excluded or grouped operators instead of moving them", func(t *testing.T) {
+		date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		dork := NewGoogleSearch().
+			Site("a.com").
+			Exclude(NewGoogleSearch().After(date)).
+			Group(NewGoogleSearch().Before(date).Or().Site("b.com"))
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("https://duckduckgo.com/?q=site%3Aa.com+%28site%3Ab.com%29", result.URL(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpAfter, Value: "2020-01-01", Kind: Dropped},
+			{Operator: query.OpBefore, Value: "2020-01-01", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should convert URL parameters", func(t *testing.T) {
+		dork := NewGoogleSearch().
+			Site("a.com").
+			TimeRange(googlesearch.PastWeek).
+			CountryRestrict("fr").
+			SafeSearch(googlesearch.SafeSearchOff).
+			ResultType(googlesearch.News)
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("https://duckduckgo.com/?df=w&ia=news&iar=news&kl=fr-fr&kp=-2&q=site%3Aa.com", result.URL(), "they should be equal")
+		assert.Empty(incompatibilities)
+
+		dork2 := NewDuckDuckGo().
+			Site("a.com").
+			Region("de-de").
+			DateRange(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)).
+			Vertical(duckduckgo.Images)
+
+		result, incompatibilities, err = Translate(dork2, googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("https://www.google.com/search?cr=countryDE&lr=lang_de&q=site%3Aa.com&tbm=isch&tbs=cdr%3A1%2Ccd_min%3A6%2F1%2F2019%2Ccd_max%3A1%2F1%2F2020", result.URL(), "they should be equal")
+		assert.Empty(incompatibilities)
+	})
+
+	t.Run("should report URL parameters without equivalent", func(t *testing.T) {
+		dork := NewGoogleSearch().
+			Site("a.com").
+			TimeRange(googlesearch.PastHour).
+			HostLanguage("fr").
+			Num(50)
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Nil(result.(*duckduckgo.DuckDuckGo).Err())
+		assert.Equal("https://duckduckgo.com/?q=site%3Aa.com", result.URL(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: "tbs", Value: "qdr:h", Kind: Dropped, Param: true},
+			{Operator: "hl", Value: "fr", Kind: Dropped, Param: true},
+			{Operator: "num", Value: "50", Kind: Dropped, Param: true},
+		}, incompatibilities, "they should be equal")
+		assert.Equal("hl=fr dropped", incompatibilities[1].String(), "they should be equal")
+	})
+
+	t.Run("should report bangs as dropped", func(t *testing.T) {
+		result, incompatibilities, err := Translate(NewDuckDuckGo().Bang("g").Site("a.com"), googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("site:a.com", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: "bang", Value: "g", Kind: Dropped, Param: true},
+		}, incompatibilities, "they should be equal")
+		assert.Equal("bang=g dropped", incompatibilities[0].String(), "they should be equal")
+
+		_, incompatibilities, err = Translate(NewSearXNG("https://searx.example.org").EngineBang("go").Site("a.com"), googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.Equal([]Incompatibility{
+			{Operator: "bang", Value: "go", Kind: Dropped, Param: true},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should keep URL parameters when translating to the same engine", func(t *testing.T) {
+		dork := NewGoogleSearch(googlesearch.WithGoogleDomain("google.fr"), googlesearch.WithStrictMode()).Site("a.com").HostLanguage("fr")
+
+		result, incompatibilities, err := Translate(dork, googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.Equal(dork.URL(), result.URL(), "they should be equal")
+		assert.Empty(incompatibilities)
+		assert.NotSame(dork, result)
+
+		result.(*googlesearch.GoogleSearch).Cache("a.com")
+		assert.True(errors.Is(result.(*googlesearch.GoogleSearch).Err(), query.ErrDeprecatedOperator), "it should be a deprecated operator error")
+		assert.Nil(dork.Err())
+
+		dork1 := NewDuckDuckGo(duckduckgo.WithBaseURL("https://html.duckduckgo.com/html/")).Site("a.com").Bang("g")
+
+		result, incompatibilities, err = Translate(dork1, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal(dork1.URL(), result.URL(), "they should be equal")
+		assert.Empty(incompatibilities)
+
+		dork2 := NewYandex().Site("a.com").Region(213)
+
+		result, incompatibilities, err = Translate(dork2, "yandex")
+
+		assert.Nil(err)
+		assert.Equal(dork2.URL(), result.URL(), "they should be equal")
+		assert.Empty(incompatibilities)
+	})
+
+	t.Run("should approximate or drop primitives the target engine doesn't support", func(t *testing.T) {
+		dork := googlesearch.FromQuery(query.Query{
+			query.Operator{Name: query.OpSite, Value: "a.com"},
+			query.Around{Left: query.Phrase{Text: "admin"}, Right: query.Phrase{Text: "panel"}, Distance: 3},
+			query.Term{Text: "price"},
+			query.Range{Low: "10", High: "100"},
+			query.Wildcard{},
+			query.Not{Node: query.Around{Left: query.Phrase{Text: "a"}, Right: query.Phrase{Text: "b"}, Distance: 2}},
+		})
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal(`site:a.com "admin" "panel" price (-"a" | -"b")`, result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.TagAround, Value: "3", Kind: Approximated, Replacement: query.TagAnd},
+			{Operator: query.TagRange, Value: "10..100", Kind: Dropped},
+			{Operator: query.TagWildcard, Value: "*", Kind: Dropped},
+			{Operator: query.TagAround, Value: "2", Kind: Approximated, Replacement: query.TagAnd},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should rewrite excluded groups for engines that can't exclude them", func(t *testing.T) {
+		dork, err := googlesearch.Parse(`-(site:a.com | site:b.com) intext:"x"`)
+		assert.Nil(err)
+
+		result, incompatibilities, err := Translate(dork, duckduckgo.EngineName)
+
+		assert.Nil(err)
+		assert.Equal(`-site:a.com -site:b.com intext:"x"`, result.String(), "they should be equal")
+		assert.Nil(result.(*duckduckgo.DuckDuckGo).Validate())
+		assert.Empty(incompatibilities)
+
+		dork, err = googlesearch.Parse(`-(inurl:"login" -(site:a.com | site:b.com)) | intitle:"x"`)
+		assert.Nil(err)
+
+		result, incompatibilities, err = Translate(dork, "baidu")
+
+		assert.Nil(err)
+		assert.Empty(incompatibilities)
+		assert.Equal(`(-inurl:"login" | site:a.com | site:b.com) | intitle:"x"`, result.String(), "they should be equal")
+		assert.Nil(result.(*baidu.Baidu).Validate())
+
+		result, _, err = Translate(dork, "bing")
+
+		assert.Nil(err)
+		assert.Equal(`-(inurl:"login" -(site:a.com OR site:b.com)) OR intitle:"x"`, result.String(), "they should be equal")
+	})
+
+	t.Run("should keep primitives the target engine supports", func(t *testing.T) {
+		q, err := googlesearch.Parse(`"admin" AROUND(3) "panel" | 10..100 *`)
+		assert.Nil(err)
+
+		result, incompatibilities, err := Translate(q, "startpage")
+
+		assert.Nil(err)
+		assert.Equal(`"admin" AROUND(3) "panel" | 10..100 *`, result.String(), "they should be equal")
+		assert.Empty(incompatibilities)
+
+		result, _, err = Translate(q, "bing")
+
+		assert.Nil(err)
+		assert.Equal(`("admin" "panel")`, result.String(), "they should be equal")
+	})
+
+	t.Run("should translate using the target syntax", func(t *testing.T) {
+		result, _, err := Translate(NewGoogleSearch().InText("admin"), "bing")
+
+		assert.Nil(err)
+		assert.Equal("inbody:\"admin\"", result.String(), "they should be equal")
+	})
+
+	t.Run("should translate a GoogleSearch to GitHub code search", func(t *testing.T) {
+		dork := NewGoogleSearch().
+			Site("github.com").
+			Ext("pem").
+			Plain("PRIVATE")
+
+		result, incompatibilities, err := Translate(dork, "github")
+
+		assert.Nil(err)
+		assert.Equal("extension:pem PRIVATE", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpSite, Value: "github.com", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should drop regular expressions the target engine doesn't support", func(t *testing.T) {
+		dork := NewGitHubSearch().Regex(`AKIA[0-9A-Z]{16}`).Extension("env")
+
+		result, incompatibilities, err := Translate(dork, googlesearch.EngineName)
+
+		assert.Nil(err)
+		assert.Equal("ext:env", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.TagRegex, Value: "AKIA[0-9A-Z]{16}", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should validate the translated dork", func(t *testing.T) {
+		dork, err := googlesearch.Parse(`-site:a.com intext:"x"`)
+		assert.Nil(err)
+
+		result, incompatibilities, err := Translate(dork, "yandex")
+
+		assert.True(errors.Is(err, query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+		assert.EqualError(err, "tag 0: invalid exclusion: ~~ needs a preceding tag")
+		assert.Equal("~~ site:a.com", result.String(), "they should be equal")
+		assert.Equal([]Incompatibility{
+			{Operator: query.OpInText, Value: "x", Kind: Dropped},
+		}, incompatibilities, "they should be equal")
+	})
+
+	t.Run("should fail with an unknown engine", func(t *testing.T) {
+		_, _, err := Translate(NewGoogleSearch(), "altavista")
+
+		assert.True(errors.Is(err, ErrUnknownEngine), "it should be an unknown engine error")
+	})
+}
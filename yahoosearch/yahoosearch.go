@@ -0,0 +1,241 @@
+package yahoosearch
+
+import (
+	"net/url"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "yahoo"
+
+const (
+	searchURL   = "https://search.yahoo.com/search"
+	siteTag     = "site:"
+	urlTag      = "inurl:"
+	filetypeTag = "filetype:"
+	excludeTag  = "-"
+	intitleTag  = "intitle:"
+	operatorOr  = "|"
+	operatorAnd = "+"
+)
+
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:     siteTag,
+		query.OpInURL:    urlTag,
+		query.OpFileType: filetypeTag,
+		query.OpInTitle:  intitleTag,
+	},
+	And: operatorAnd,
+	Or:  operatorOr,
+	Not: excludeTag,
+}
+
+// YahooSearch is the Yahoo search implementation for Dorkgen
+type YahooSearch struct {
+	nodes   query.Query
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of YahooSearch
+type Option func(*YahooSearch)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *YahooSearch) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *YahooSearch) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of YahooSearch
+func New(opts ...Option) *YahooSearch {
+	e := &YahooSearch{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of YahooSearch from a query tree
+func FromQuery(q query.Query) *YahooSearch {
+	return &YahooSearch{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of YahooSearch
+func Parse(dork string) (*YahooSearch, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &YahooSearch{nodes: q}, nil
+}
+
+// Render converts a query tree to Yahoo Search syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in Yahoo Search
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Yahoo Search
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *YahooSearch) add(n query.Node) *YahooSearch {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *YahooSearch) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *YahooSearch) operator(name string, value string, quotes bool) *YahooSearch {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *YahooSearch) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *YahooSearch) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *YahooSearch) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+func (e *YahooSearch) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	return query.Validate(e.nodes)
+}
+
+// String converts all tags to a single request
+func (e *YahooSearch) String() string {
+	return syntax.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values
+func (e *YahooSearch) QueryValues() url.Values {
+	params := url.Values{}
+	params.Add("p", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded Yahoo Search URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *YahooSearch) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded Yahoo Search URL, or returns an error if the base URL is invalid.
+func (e *YahooSearch) URLE() (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, e.QueryValues())
+}
+
+// Site specifically searches that particular site and lists all the results for that site.
+func (e *YahooSearch) Site(site string) *YahooSearch {
+	return e.operator(query.OpSite, site, false)
+}
+
+// Or puts an OR operator in the request
+func (e *YahooSearch) Or() *YahooSearch {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request
+func (e *YahooSearch) And() *YahooSearch {
+	return e.add(query.And{})
+}
+
+// InURL searches for a URL matching one of the keywords.
+func (e *YahooSearch) InURL(url string) *YahooSearch {
+	return e.operator(query.OpInURL, url, true)
+}
+
+// FileType searches for a particular filetype mentioned in the query.
+func (e *YahooSearch) FileType(filetype string) *YahooSearch {
+	return e.operator(query.OpFileType, filetype, true)
+}
+
+// Exclude excludes results matching any of the given tags, by negating each of them.
+// Yahoo Search doesn't support excluding groups, which report an error.
+func (e *YahooSearch) Exclude(tags *YahooSearch) *YahooSearch {
+	nodes, err := query.Exclude(tags.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag.
+func (e *YahooSearch) Not(tag *YahooSearch) *YahooSearch {
+	n, err := query.Negate(tag.Query(), false)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *YahooSearch) Group(tags *YahooSearch) *YahooSearch {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// InTitle searches for occurrences of keywords in title all or one.
+func (e *YahooSearch) InTitle(value string) *YahooSearch {
+	return e.operator(query.OpInTitle, value, true)
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *YahooSearch) Plain(value string) *YahooSearch {
+	return e.add(query.Term{Text: value})
+}
@@ -0,0 +1,157 @@
+package yahoosearch_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/yahoosearch"
+	"net/url"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+var dork *yahoosearch.YahooSearch
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal(result, "https://search.yahoo.com/search?p=site%3Aexample.com", "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal(result, "site:example.com", "they should be equal")
+	})
+
+	t.Run("should handle site tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			Site("example.com").
+			String()
+
+		assert.Equal(result, "site:example.com", "they should be equal")
+	})
+
+	t.Run("should handle inurl tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			InURL("index.php").
+			String()
+
+		assert.Equal(result, "inurl:\"index.php\"", "they should be equal")
+	})
+
+	t.Run("should handle filetype tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			FileType("pdf").
+			String()
+
+		assert.Equal(result, "filetype:\"pdf\"", "they should be equal")
+	})
+
+	t.Run("should handle intitle tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			InTitle("admin").
+			String()
+
+		assert.Equal(result, "intitle:\"admin\"", "they should be equal")
+	})
+
+	t.Run("should handle exclude tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			Exclude(yahoosearch.New().Plain("html")).
+			Exclude(yahoosearch.New().Plain("htm")).
+			Exclude(yahoosearch.New().Plain("php")).
+			String()
+
+		assert.Equal(result, "-html -htm -php", "they should be equal")
+	})
+
+	t.Run("should handle 'OR' tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			Site("facebook.com").
+			Or().
+			Site("twitter.com").
+			String()
+
+		assert.Equal(result, "site:facebook.com | site:twitter.com", "they should be equal")
+	})
+
+	t.Run("should handle 'AND' tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			InTitle("facebook").
+			And().
+			InTitle("twitter").
+			String()
+
+		assert.Equal(result, "intitle:\"facebook\" + intitle:\"twitter\"", "they should be equal")
+	})
+
+	t.Run("should handle group tag correctly", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			Site("linkedin.com").
+			Group(yahoosearch.New().InTitle("1").Or().InTitle("2")).
+			InURL("jordan").
+			String()
+
+		assert.Equal(result, "site:linkedin.com (intitle:\"1\" | intitle:\"2\") inurl:\"jordan\"", "they should be equal")
+	})
+
+	t.Run("should return URL values", func(t *testing.T) {
+		dork = yahoosearch.New()
+
+		result := dork.
+			Site("linkedin.com").
+			Group(yahoosearch.New().InTitle("1").Or().InTitle("2")).
+			QueryValues()
+
+		assert.Equal(url.Values{
+			"p": []string{"site:linkedin.com (intitle:\"1\" | intitle:\"2\")"},
+		}, result, "they should be equal")
+	})
+
+	t.Run("should sanitize quoted values", func(t *testing.T) {
+		dork = yahoosearch.New().InTitle("say \"hi\"")
+
+		assert.Nil(dork.Err())
+		assert.Equal("intitle:\"say hi\"", dork.String(), "they should be equal")
+
+		dork = yahoosearch.New(yahoosearch.WithEscapePolicy(query.RejectQuotes)).InTitle("say \"hi\"")
+
+		assert.True(errors.Is(dork.Err(), query.ErrUnbalancedQuote), "it should be an unbalanced quote error")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = yahoosearch.New(yahoosearch.WithBaseURL("https://fr.search.yahoo.com/search?fr=yfp")).Site("example.com")
+
+		assert.Equal("https://fr.search.yahoo.com/search?fr=yfp&p=site%3Aexample.com", dork.URL(), "they should be equal")
+
+		_, err := yahoosearch.New(yahoosearch.WithBaseURL("/search")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+}
@@ -0,0 +1,33 @@
+package yandex
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+func (e *Yandex) set(param string, value string) *Yandex {
+	if e.params == nil {
+		e.params = url.Values{}
+	}
+	e.params.Set(param, value)
+	return e
+}
+
+func (e *Yandex) invalid(param string, value interface{}) *Yandex {
+	if e.err == nil {
+		e.err = fmt.Errorf("%w: %s=%v", query.ErrInvalidParameter, param, value)
+	}
+	return e
+}
+
+// Region restricts results to a region, using its Yandex region ID such as 213 for Moscow (lr parameter).
+// See https://yandex.com/dev/xml/doc/dg/reference/regions.html for the list of regions.
+func (e *Yandex) Region(id int) *Yandex {
+	if id <= 0 {
+		return e.invalid("lr", id)
+	}
+	return e.set("lr", strconv.Itoa(id))
+}
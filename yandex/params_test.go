@@ -0,0 +1,28 @@
+package yandex_test
+
+import (
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/yandex"
+)
+
+func TestParams(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should set the region", func(t *testing.T) {
+		dork = yandex.New().Site("example.com").Region(213)
+
+		assert.Nil(dork.Err())
+		assert.Equal("https://yandex.com/search/?lr=213&text=site%3Aexample.com", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should reject invalid regions", func(t *testing.T) {
+		dork = yandex.New().Region(0)
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidParameter), "it should be an invalid parameter error")
+		assert.EqualError(dork.Err(), "invalid parameter: lr=0")
+	})
+}
@@ -0,0 +1,372 @@
+package yandex
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sundowndev/dorkgen/query"
+)
+
+// EngineName is the name identifying this search engine.
+const EngineName = "yandex"
+
+const (
+	searchURL   = "https://yandex.com/search/"
+	siteTag     = "site:"
+	hostTag     = "host:"
+	domainTag   = "domain:"
+	urlTag      = "url:"
+	inurlTag    = "inurl:"
+	titleTag    = "title:"
+	mimeTag     = "mime:"
+	langTag     = "lang:"
+	dateTag     = "date:"
+	excludeTag  = "~~ "
+	exactTag    = "!"
+	operatorOr  = "|"
+	operatorAnd = "&&"
+	dateFormat  = "20060102"
+)
+
+var syntax = &query.Syntax{
+	Operators: map[string]string{
+		query.OpSite:     siteTag,
+		query.OpHost:     hostTag,
+		query.OpDomain:   domainTag,
+		query.OpURL:      urlTag,
+		query.OpInURL:    inurlTag,
+		query.OpInTitle:  titleTag,
+		query.OpFileType: mimeTag,
+		query.OpLanguage: langTag,
+		query.OpDate:     dateTag,
+	},
+	And: operatorAnd,
+	Or:  operatorOr,
+	Not: excludeTag,
+}
+
+// errLeadingExclusion is returned for an exclusion without any tag to exclude results from.
+var errLeadingExclusion = fmt.Errorf("%w: ~~ needs a preceding tag", query.ErrInvalidExclusion)
+
+// Yandex is the Yandex search implementation for Dorkgen
+type Yandex struct {
+	nodes   query.Query
+	params  url.Values
+	err     error
+	escape  query.EscapePolicy
+	baseURL string
+}
+
+// Option configures an instance of Yandex
+type Option func(*Yandex)
+
+// WithEscapePolicy defines how quotes embedded in quoted values are handled. Defaults to query.StripQuotes.
+func WithEscapePolicy(policy query.EscapePolicy) Option {
+	return func(e *Yandex) {
+		e.escape = policy
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as a proxy or a local server.
+// Query parameters of the base URL are kept, unless they are overridden by the request.
+func WithBaseURL(baseURL string) Option {
+	return func(e *Yandex) {
+		e.baseURL = baseURL
+	}
+}
+
+// New creates a new instance of Yandex
+func New(opts ...Option) *Yandex {
+	e := &Yandex{}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// FromQuery creates a new instance of Yandex from a query tree
+func FromQuery(q query.Query) *Yandex {
+	return &Yandex{nodes: q.Copy()}
+}
+
+// Parse converts a dork string into a new instance of Yandex
+func Parse(dork string) (*Yandex, error) {
+	q, err := syntax.Parse(dork)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Yandex{nodes: q}, nil
+}
+
+// Render converts a query tree to Yandex syntax
+func Render(q query.Query) string {
+	return syntax.Render(q)
+}
+
+// Supports reports whether the operator is available in Yandex
+func Supports(operator string) bool {
+	return syntax.Supports(operator)
+}
+
+// HasFeature reports whether the query primitive, such as proximity searches, is available in Yandex
+func HasFeature(f query.Feature) bool {
+	return syntax.Has(f)
+}
+
+func (e *Yandex) add(n query.Node) *Yandex {
+	if err := query.ValidateNext(e.nodes, n); err != nil {
+		e.fail(err)
+	}
+	e.nodes = append(e.nodes, n)
+	return e
+}
+
+// fail records the error for the next tag, unless an error already occurred.
+func (e *Yandex) fail(err error) {
+	if e.err == nil {
+		e.err = &query.ValidationError{Index: len(e.nodes), Err: err}
+	}
+}
+
+func (e *Yandex) operator(name string, value string, quotes bool) *Yandex {
+	value, err := query.Sanitize(value, quotes, e.escape)
+	if err != nil {
+		e.fail(err)
+	}
+
+	return e.add(query.Operator{Name: name, Value: value, Quoted: quotes})
+}
+
+// Name returns the name of the search engine
+func (e *Yandex) Name() string {
+	return EngineName
+}
+
+// Query returns a copy of the query tree
+func (e *Yandex) Query() query.Query {
+	return e.nodes.Copy()
+}
+
+// Err returns the first error encountered while adding tags, if any.
+// Use Validate to check the whole request once it's complete.
+func (e *Yandex) Err() error {
+	return e.err
+}
+
+// Validate checks the request is well-formed and returns a *query.ValidationError
+// carrying the index of the first offending tag.
+// Since ~~ excludes results from the ones matching the previous tags, the request can't start with an exclusion.
+func (e *Yandex) Validate() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if i := leadingExclusion(e.nodes); i >= 0 {
+		return &query.ValidationError{Index: i, Err: errLeadingExclusion}
+	}
+	for i, n := range e.nodes {
+		leading := false
+		query.Walk(query.Query{n}, func(n query.Node) bool {
+			if g, ok := n.(query.Group); ok && leadingExclusion(g.Nodes) >= 0 {
+				leading = true
+			}
+			return !leading
+		})
+		if leading {
+			return &query.ValidationError{Index: i, Err: errLeadingExclusion}
+		}
+	}
+
+	return query.Validate(e.nodes)
+}
+
+// leadingExclusion returns the index of the first exclusion that doesn't follow a tag,
+// at the start of the query or right after OR or AND, or -1 if there is none.
+func leadingExclusion(q query.Query) int {
+	for i, n := range q {
+		if _, ok := n.(query.Not); ok && !follows(q[:i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// follows reports whether an exclusion can be appended to the given tags.
+func follows(q query.Query) bool {
+	if len(q) == 0 {
+		return false
+	}
+
+	switch q[len(q)-1].(type) {
+	case query.Or, query.And:
+		return false
+	}
+	return true
+}
+
+// String converts all tags to a single request
+func (e *Yandex) String() string {
+	return syntax.Render(e.nodes)
+}
+
+// QueryValues returns search request as URL values, including URL parameters
+func (e *Yandex) QueryValues() url.Values {
+	params := url.Values{}
+	for k, v := range e.params {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("text", e.String())
+
+	return params
+}
+
+// URL converts tags to an encoded Yandex URL.
+// It returns an empty string if the base URL is invalid, use URLE to get the error.
+func (e *Yandex) URL() string {
+	u, _ := e.URLE()
+	return u
+}
+
+// URLE converts tags to an encoded Yandex URL, or returns an error if the base URL is invalid.
+func (e *Yandex) URLE() (string, error) {
+	return query.BuildURL(e.baseURL, searchURL, e.QueryValues())
+}
+
+// Site searches pages of the site and all its subdomains.
+func (e *Yandex) Site(site string) *Yandex {
+	return e.operator(query.OpSite, site, false)
+}
+
+// Host searches pages of the given host only, such as "www.example.com".
+func (e *Yandex) Host(host string) *Yandex {
+	return e.operator(query.OpHost, host, false)
+}
+
+// Domain searches pages of sites in the given top-level domain, such as "ru".
+func (e *Yandex) Domain(domain string) *Yandex {
+	return e.operator(query.OpDomain, domain, false)
+}
+
+// ExactURL searches for the page at the given address, using the url: operator.
+// Use "*" at the end of the address to search for all pages under a path.
+// It is not named URL to avoid conflicting with the URL conversion method.
+func (e *Yandex) ExactURL(url string) *Yandex {
+	return e.operator(query.OpURL, url, false)
+}
+
+// InURL searches for pages with the keyword in their address.
+func (e *Yandex) InURL(url string) *Yandex {
+	return e.operator(query.OpInURL, url, true)
+}
+
+// Title searches for pages with the keywords in their title.
+func (e *Yandex) Title(title string) *Yandex {
+	return e.operator(query.OpInTitle, title, true)
+}
+
+// Mime searches for documents of the given type, such as "pdf" or "doc".
+func (e *Yandex) Mime(filetype string) *Yandex {
+	return e.operator(query.OpFileType, filetype, false)
+}
+
+// Lang searches for pages in the given language, such as "ru" or "en".
+func (e *Yandex) Lang(lang string) *Yandex {
+	return e.operator(query.OpLanguage, lang, false)
+}
+
+// Date searches for pages last modified on the given day.
+func (e *Yandex) Date(date time.Time) *Yandex {
+	return e.operator(query.OpDate, date.Format(dateFormat), false)
+}
+
+// Before searches for pages last modified before the given day.
+func (e *Yandex) Before(date time.Time) *Yandex {
+	return e.operator(query.OpDate, "<"+date.Format(dateFormat), false)
+}
+
+// After searches for pages last modified after the given day.
+func (e *Yandex) After(date time.Time) *Yandex {
+	return e.operator(query.OpDate, ">"+date.Format(dateFormat), false)
+}
+
+// Between searches for pages last modified between the given days, such as date:20200101..20201231.
+func (e *Yandex) Between(from, to time.Time) *Yandex {
+	if to.Before(from) {
+		e.fail(query.ErrInvalidDateRange)
+		return e
+	}
+
+	return e.operator(query.OpDate, from.Format(dateFormat)+".."+to.Format(dateFormat), false)
+}
+
+// Exact searches for the word in the exact form given, without any other grammatical form.
+func (e *Yandex) Exact(word string) *Yandex {
+	if word == "" || strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+		e.fail(fmt.Errorf("%w: %q", query.ErrInvalidTerm, word))
+	}
+
+	return e.add(query.Term{Text: exactTag + word})
+}
+
+// Or puts an OR operator in the request
+func (e *Yandex) Or() *Yandex {
+	return e.add(query.Or{})
+}
+
+// And puts an AND operator in the request, requiring both sides to be in the same document.
+func (e *Yandex) And() *Yandex {
+	return e.add(query.And{})
+}
+
+// Exclude excludes results matching any of the given tags from the results of the previous tags, by negating each of them.
+// Yandex supports excluding a group as a whole.
+func (e *Yandex) Exclude(tags *Yandex) *Yandex {
+	if !follows(e.nodes) {
+		e.fail(errLeadingExclusion)
+		return e
+	}
+
+	nodes, err := query.Exclude(tags.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	for _, n := range nodes {
+		e.add(n)
+	}
+
+	return e
+}
+
+// Not excludes results matching a single tag from the results of the previous tags.
+func (e *Yandex) Not(tag *Yandex) *Yandex {
+	if !follows(e.nodes) {
+		e.fail(errLeadingExclusion)
+		return e
+	}
+
+	n, err := query.Negate(tag.Query(), true)
+	if err != nil {
+		e.fail(err)
+		return e
+	}
+
+	return e.add(n)
+}
+
+// Group isolate tags between parentheses
+func (e *Yandex) Group(tags *Yandex) *Yandex {
+	return e.add(query.Group{Nodes: tags.Query()})
+}
+
+// Plain allows you to add additional values as string without any kind of formatting.
+func (e *Yandex) Plain(value string) *Yandex {
+	return e.add(query.Term{Text: value})
+}
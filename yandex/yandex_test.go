@@ -0,0 +1,203 @@
+package yandex_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/sundowndev/dorkgen/googlesearch"
+	"github.com/sundowndev/dorkgen/query"
+	"github.com/sundowndev/dorkgen/yandex"
+)
+
+var dork *yandex.Yandex
+
+func TestInit(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should convert to URL correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := dork.
+			Site("example.com").
+			URL()
+
+		assert.Equal("https://yandex.com/search/?text=site%3Aexample.com", result, "they should be equal")
+	})
+
+	t.Run("should convert to string correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := fmt.Sprint(dork.Site("example.com"))
+
+		assert.Equal("site:example.com", result, "they should be equal")
+	})
+
+	t.Run("should handle site operators correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := dork.
+			Site("example.com").
+			Host("www.example.com").
+			Domain("ru").
+			ExactURL("example.com/admin*").
+			String()
+
+		assert.Equal("site:example.com host:www.example.com domain:ru url:example.com/admin*", result, "they should be equal")
+	})
+
+	t.Run("should handle content operators correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := dork.
+			InURL("login").
+			Title("панель управления").
+			Mime("pdf").
+			Lang("ru").
+			String()
+
+		assert.Equal("inurl:\"login\" title:\"панель управления\" mime:pdf lang:ru", result, "they should be equal")
+	})
+
+	t.Run("should handle date operators correctly", func(t *testing.T) {
+		from := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+
+		assert.Equal("date:20200102", yandex.New().Date(from).String(), "they should be equal")
+		assert.Equal("date:<20200102", yandex.New().Before(from).String(), "they should be equal")
+		assert.Equal("date:>20200102", yandex.New().After(from).String(), "they should be equal")
+		assert.Equal("date:20200102..20201231", yandex.New().Between(from, to).String(), "they should be equal")
+		assert.True(errors.Is(yandex.New().Between(to, from).Err(), query.ErrInvalidDateRange), "it should be an invalid date range error")
+	})
+
+	t.Run("should handle exact word forms correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := dork.
+			Exact("пароль").
+			And().
+			Exact("admin").
+			String()
+
+		assert.Equal("!пароль && !admin", result, "they should be equal")
+		assert.True(errors.Is(yandex.New().Exact("two words").Err(), query.ErrInvalidTerm), "it should be an invalid term error")
+	})
+
+	t.Run("should handle or and groups correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := dork.
+			Site("example.com").
+			Group(yandex.New().Mime("pdf").Or().Mime("doc")).
+			String()
+
+		assert.Equal("site:example.com (mime:pdf | mime:doc)", result, "they should be equal")
+	})
+
+	t.Run("should handle exclusions correctly", func(t *testing.T) {
+		dork = yandex.New()
+
+		result := dork.
+			Site("example.com").
+			Exclude(yandex.New().InURL("login").Mime("pdf")).
+			String()
+
+		assert.Equal("site:example.com ~~ inurl:\"login\" ~~ mime:pdf", result, "they should be equal")
+		assert.Nil(dork.Validate())
+	})
+
+	t.Run("should reject leading exclusions", func(t *testing.T) {
+		dork = yandex.New().Not(yandex.New().InURL("login"))
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+
+		parsed, err := yandex.Parse(`~~ inurl:"login" site:example.com`)
+
+		assert.Nil(err)
+		assert.EqualError(parsed.Validate(), "tag 0: invalid exclusion: ~~ needs a preceding tag")
+
+		dork = yandex.New().Site("example.com").Or().Not(yandex.New().InURL("login"))
+
+		assert.True(errors.Is(dork.Err(), query.ErrInvalidExclusion), "it should be an invalid exclusion error")
+
+		for dork, index := range map[string]int{
+			`site:example.com | ~~ inurl:"login"`:          2,
+			`site:example.com && ~~ inurl:"login"`:         2,
+			`site:example.com (~~ inurl:"login" mime:pdf)`: 1,
+			`site:example.com ~~ (mime:pdf | ~~ mime:doc)`: 1,
+		} {
+			parsed, err := yandex.Parse(dork)
+
+			assert.Nil(err)
+			assert.EqualError(parsed.Validate(), fmt.Sprintf("tag %d: invalid exclusion: ~~ needs a preceding tag", index), dork)
+		}
+
+		parsed, err = yandex.Parse(`site:example.com (mime:pdf ~~ inurl:"login") | title:"admin" ~~ lang:ru`)
+
+		assert.Nil(err)
+		assert.Nil(parsed.Validate())
+	})
+
+	t.Run("should encode UTF-8 keywords in the URL", func(t *testing.T) {
+		dork = yandex.New().Title("пароль")
+
+		assert.Equal("https://yandex.com/search/?text=title%3A%22%D0%BF%D0%B0%D1%80%D0%BE%D0%BB%D1%8C%22", dork.URL(), "they should be equal")
+	})
+
+	t.Run("should use a custom base URL", func(t *testing.T) {
+		dork = yandex.New(yandex.WithBaseURL("https://yandex.ru/search/?lr=1")).Site("example.com")
+
+		assert.Equal("https://yandex.ru/search/?lr=1&text=site%3Aexample.com", dork.URL(), "they should be equal")
+
+		_, err := yandex.New(yandex.WithBaseURL("/search")).URLE()
+		assert.True(errors.Is(err, query.ErrInvalidBaseURL), "it should be an invalid base URL error")
+	})
+}
+
+func TestParse(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should round trip", func(t *testing.T) {
+		dork = yandex.New().
+			Site("example.com").
+			Exclude(yandex.New().InURL("login")).
+			Or().
+			Group(yandex.New().Title("admin").And().Lang("ru")).
+			Exact("пароль").
+			Between(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		result, err := yandex.Parse(dork.String())
+
+		assert.Nil(err)
+		assert.Equal(dork.Query(), result.Query(), "they should be equal")
+		assert.Equal(dork.String(), result.String(), "they should be equal")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Run("should render a query built for another engine", func(t *testing.T) {
+		q := googlesearch.New().
+			Site("example.com").
+			InTitle("admin").
+			FileType("pdf").
+			Query()
+
+		assert.Equal("site:example.com title:\"admin\" mime:\"pdf\"", yandex.Render(q), "they should be equal")
+		assert.True(yandex.Supports(query.OpHost))
+		assert.False(yandex.Supports(query.OpCache))
+	})
+
+	t.Run("should set URL parameters", func(t *testing.T) {
+		dork = yandex.New().Site("example.com").Region(213)
+
+		assert.Equal(url.Values{
+			"text": []string{"site:example.com"},
+			"lr":   []string{"213"},
+		}, dork.QueryValues(), "they should be equal")
+	})
+}